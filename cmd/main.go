@@ -52,6 +52,13 @@ func main() {
 	gitLabWrapper := wrappers.NewGitLabWrapper()
 	bflWrapper := wrappers.NewBflHTTPWrapper(bfl)
 	learnMoreWrapper := wrappers.NewHTTPLearnMoreWrapper(descriptionsPath)
+	hooksWrapper := wrappers.NewHTTPHooksWrapper()
+	healthCheckWrapper := wrappers.NewHTTPHealthCheckWrapper(
+		viper.GetString(params.HealthcheckPathKey),
+		viper.GetString(params.AstWebAppHealthCheckPathKey),
+		viper.GetString(params.AstKeycloakWebAppHealthCheckPathKey),
+	)
+	diagnosticsWrapper := wrappers.NewDiagnosticsHTTPWrapper()
 
 	astCli := commands.NewAstCLI(
 		scansWrapper,
@@ -69,6 +76,9 @@ func main() {
 		gitLabWrapper,
 		bflWrapper,
 		learnMoreWrapper,
+		hooksWrapper,
+		healthCheckWrapper,
+		diagnosticsWrapper,
 	)
 	exitListener()
 	err = astCli.Execute()
@@ -0,0 +1,477 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedDoctor        = "Failed running the diagnostics"
+	doctorPassed        = "Passed"
+	doctorFailed        = "Failed"
+	doctorWarning       = "Warning"
+	doctorSkipped       = "Skipped"
+	doctorRedacted      = "******"
+	doctorSourceFlag    = "flag"
+	doctorSourceEnv     = "environment"
+	doctorSourceConfig  = "config file"
+	doctorSourceDefault = "default"
+	doctorNotSet        = "not set"
+	defaultHTTPSPort    = "443"
+	defaultHTTPPort     = "80"
+	httpsScheme         = "https"
+	doctorDateFormat    = "2006-01-02"
+	ntlmProxyType       = "ntlm"
+	// The report does not hold secrets, but it describes the network of the user
+	doctorReportPermissions = 0600
+)
+
+type doctorConfigView struct {
+	Key    string `json:"key" format:"name:Key"`
+	Value  string `json:"value" format:"name:Value"`
+	Source string `json:"source" format:"name:Source"`
+}
+
+type doctorCheckView struct {
+	Check     string `json:"check" format:"name:Check"`
+	Status    string `json:"status" format:"name:Status"`
+	LatencyMs int64  `json:"latencyMs" format:"name:Latency (ms)"`
+	Details   string `json:"details" format:"name:Details"`
+}
+
+type doctorReport struct {
+	GeneratedAt   time.Time                       `json:"generatedAt"`
+	Version       string                          `json:"version"`
+	OS            string                          `json:"os"`
+	Arch          string                          `json:"arch"`
+	ConfigFile    string                          `json:"configFile"`
+	Configuration []doctorConfigView              `json:"configuration"`
+	Checks        []doctorCheckView               `json:"checks"`
+	Certificates  map[string]*wrappers.TLSDetails `json:"certificates,omitempty"`
+}
+
+// doctorSetting is a configuration entry with the flag and environment variable that can set it
+type doctorSetting struct {
+	key    string
+	flag   string
+	env    string
+	secret bool
+}
+
+var doctorSettings = []doctorSetting{
+	{commonParams.BaseURIKey, commonParams.BaseURIFlag, commonParams.BaseURIEnv, false},
+	{commonParams.BaseAuthURIKey, commonParams.BaseAuthURIFlag, commonParams.BaseAuthURIEnv, false},
+	{commonParams.TenantKey, commonParams.TenantFlag, commonParams.TenantEnv, false},
+	{commonParams.AstAPIKey, commonParams.AstAPIKeyFlag, commonParams.AstAPIKeyEnv, true},
+	{commonParams.AccessKeyIDConfigKey, commonParams.AccessKeyIDFlag, commonParams.AccessKeyIDEnv, false},
+	{commonParams.AccessKeySecretConfigKey, commonParams.AccessKeySecretFlag, commonParams.AccessKeySecretEnv, true},
+	{commonParams.ProxyKey, commonParams.ProxyFlag, commonParams.ProxyEnv, false},
+	{commonParams.HTTPSProxyKey, "", commonParams.HTTPSProxyEnv, false},
+	{commonParams.NoProxyKey, commonParams.NoProxyFlag, commonParams.NoProxyEnv, false},
+	{commonParams.ProxyRulesKey, commonParams.ProxyRulesFlag, commonParams.ProxyRulesEnv, false},
+	{commonParams.ProxyTypeKey, commonParams.ProxyTypeFlag, commonParams.ProxyTypeEnv, false},
+	{commonParams.ProxyDomainKey, commonParams.NtlmProxyDomainFlag, commonParams.ProxyDomainEnv, false},
+	{commonParams.ProxyKrb5ConfKey, commonParams.KrbProxyConfFlag, commonParams.ProxyKrb5ConfEnv, false},
+	{commonParams.ProxyKeytabKey, commonParams.KrbProxyKeytabFlag, commonParams.ProxyKeytabEnv, false},
+	{commonParams.ProxyPrincipalKey, commonParams.KrbProxyPrincipalFlag, commonParams.ProxyPrincipalEnv, false},
+	{commonParams.ProxyCCacheKey, commonParams.KrbProxyCCacheFlag, commonParams.ProxyCCacheEnv, false},
+	{commonParams.ClientTimeoutKey, commonParams.TimeoutFlag, commonParams.ClientTimeoutEnv, false},
+	{commonParams.AgentNameKey, commonParams.AgentFlag, commonParams.AgentNameEnv, false},
+	{commonParams.InsecureFlag, commonParams.InsecureFlag, "", false},
+	{commonParams.CACertKey, commonParams.CACertFlag, commonParams.CACertEnv, false},
+	{commonParams.ClientCertKey, commonParams.ClientCertFlag, commonParams.ClientCertEnv, false},
+	{commonParams.ClientKeyKey, commonParams.ClientKeyFlag, commonParams.ClientKeyEnv, false},
+	{commonParams.MinTLSVersionKey, commonParams.MinTLSVersionFlag, commonParams.MinTLSVersionEnv, false},
+}
+
+func NewDoctorCommand(
+	diagnosticsWrapper wrappers.DiagnosticsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+) *cobra.Command {
+	doctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the connectivity and the configuration of the CLI",
+		Long: "The doctor command checks step by step the configuration, DNS resolution, TLS handshake, proxy, " +
+			"authentication and container engine, and writes a redacted report to attach to support tickets.",
+		Example: heredoc.Doc(
+			`
+			$ cx doctor
+			$ cx doctor --report-file report.json --engine podman
+		`,
+		),
+		RunE: runDoctorCommand(diagnosticsWrapper, projectsWrapper),
+	}
+	doctorCmd.PersistentFlags().String(
+		commonParams.ReportFileFlag,
+		commonParams.DoctorReportFileDefault,
+		commonParams.DoctorReportFileFlagUsage,
+	)
+	doctorCmd.PersistentFlags().String(
+		commonParams.KicsRealtimeEngine,
+		"docker",
+		"Name in the $PATH for the container engine to run kics. Example:podman.",
+	)
+	addFormatFlag(doctorCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+	return doctorCmd
+}
+
+func runDoctorCommand(
+	diagnosticsWrapper wrappers.DiagnosticsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		reportFile, _ := cmd.Flags().GetString(commonParams.ReportFileFlag)
+		engine, _ := cmd.Flags().GetString(commonParams.KicsRealtimeEngine)
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+
+		d := &doctor{
+			diagnosticsWrapper: diagnosticsWrapper,
+			projectsWrapper:    projectsWrapper,
+			secrets:            doctorSecrets(),
+			report: doctorReport{
+				GeneratedAt:  time.Now().UTC(),
+				Version:      commonParams.Version,
+				OS:           runtime.GOOS,
+				Arch:         runtime.GOARCH,
+				ConfigFile:   viper.ConfigFileUsed(),
+				Certificates: make(map[string]*wrappers.TLSDetails),
+			},
+		}
+		d.report.Configuration = d.getConfiguration(cmd)
+		d.checkConnectivity()
+		d.checkAuthentication()
+		d.checkContainerEngine(engine)
+
+		err := writeDoctorReport(reportFile, &d.report)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedDoctor)
+		}
+		if printer.IsFormat(format, printer.FormatJSON) {
+			err = printer.Print(cmd.OutOrStdout(), d.report, format)
+		} else {
+			err = printer.Print(cmd.OutOrStdout(), d.report.Configuration, format)
+			if err == nil {
+				err = printer.Print(cmd.OutOrStdout(), d.report.Checks, format)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Report written to %s\n", reportFile)
+
+		failed := 0
+		for _, check := range d.report.Checks {
+			if check.Status == doctorFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf("%s: %d of %d checks failed", failedDoctor, failed, len(d.report.Checks))
+		}
+		return nil
+	}
+}
+
+type doctor struct {
+	diagnosticsWrapper wrappers.DiagnosticsWrapper
+	projectsWrapper    wrappers.ProjectsWrapper
+	secrets            []string
+	report             doctorReport
+}
+
+// doctorSecrets returns the configured values that must never be written in the report
+func doctorSecrets() []string {
+	var secrets []string
+	for _, setting := range doctorSettings {
+		if value := viper.GetString(setting.key); setting.secret && value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+	proxies := []string{viper.GetString(commonParams.ProxyKey), viper.GetString(commonParams.HTTPSProxyKey)}
+	for _, rule := range strings.Split(viper.GetString(commonParams.ProxyRulesKey), ",") {
+		if separator := strings.Index(rule, "="); separator >= 0 {
+			proxies = append(proxies, strings.TrimSpace(rule[separator+1:]))
+		}
+	}
+	for _, proxy := range proxies {
+		if proxyURL, err := url.Parse(proxy); err == nil && proxyURL.User != nil {
+			if password, found := proxyURL.User.Password(); found && password != "" {
+				secrets = append(secrets, password)
+			}
+		}
+	}
+	return secrets
+}
+
+func (d *doctor) redact(value string) string {
+	for _, secret := range d.secrets {
+		value = strings.ReplaceAll(value, secret, doctorRedacted)
+	}
+	return value
+}
+
+// getConfiguration shows the effective value of every setting and where it comes from
+func (d *doctor) getConfiguration(cmd *cobra.Command) []doctorConfigView {
+	var views []doctorConfigView
+	for _, setting := range doctorSettings {
+		value := viper.GetString(setting.key)
+		view := doctorConfigView{Key: setting.key, Value: d.redact(value), Source: doctorSourceDefault}
+		if envValue, found := os.LookupEnv(setting.env); setting.env != "" && found && envValue == value {
+			view.Source = doctorSourceEnv
+		} else if viper.InConfig(setting.key) {
+			view.Source = doctorSourceConfig
+		}
+		if flag := cmd.Flags().Lookup(setting.flag); flag != nil && flag.Changed {
+			view.Source = doctorSourceFlag
+		}
+		if value == "" {
+			view.Value = doctorNotSet
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+// check runs the step and adds its outcome to the report
+func (d *doctor) check(name string, step func() (status, details string)) string {
+	start := time.Now()
+	status, details := step()
+	d.report.Checks = append(
+		d.report.Checks, doctorCheckView{
+			Check:     name,
+			Status:    status,
+			LatencyMs: time.Since(start).Milliseconds(),
+			Details:   d.redact(details),
+		},
+	)
+	return status
+}
+
+func (d *doctor) checkConnectivity() {
+	baseURI := viper.GetString(commonParams.BaseURIKey)
+	authURI := viper.GetString(commonParams.BaseAuthURIKey)
+	if authURI == "" {
+		authURI = baseURI
+	}
+	checked := make(map[string]bool)
+	var proxied []doctorProxiedEndpoint
+	baseResolved := false
+	baseProxied := false
+	for _, endpoint := range []struct{ name, uri string }{{"base URI", baseURI}, {"auth URI", authURI}} {
+		if endpoint.name == "auth URI" && authURI == baseURI {
+			continue
+		}
+		host, port, https, err := splitDoctorURI(endpoint.uri)
+		if err != nil {
+			d.check(
+				"Parse "+endpoint.name, func() (string, string) {
+					return doctorFailed, err.Error()
+				},
+			)
+			continue
+		}
+		address := net.JoinHostPort(host, port)
+		if checked[address] {
+			continue
+		}
+		checked[address] = true
+		route, err := wrappers.ResolveProxy(strings.TrimSpace(endpoint.uri))
+		// Direct connections are expected to fail when the endpoint is reached through a proxy
+		directFailure := doctorFailed
+		if err != nil || route != nil {
+			directFailure = doctorWarning
+		}
+		resolved := d.checkEndpoint(host, address, https, directFailure)
+		baseResolved = baseResolved || (endpoint.uri == baseURI && resolved)
+		baseProxied = baseProxied || (endpoint.uri == baseURI && route != nil)
+		proxied = append(proxied, doctorProxiedEndpoint{uri: strings.TrimSpace(endpoint.uri), address: address, route: route, err: err})
+	}
+
+	for _, endpoint := range proxied {
+		d.checkProxy(endpoint)
+	}
+	d.check(
+		"HTTP request to the base URI", func() (string, string) {
+			if !baseProxied && !baseResolved {
+				return doctorSkipped, "The base URI can't be reached"
+			}
+			statusCode, err := d.diagnosticsWrapper.Get(wrappers.GetURL(""))
+			if err != nil {
+				return doctorFailed, err.Error()
+			}
+			return doctorPassed, fmt.Sprintf("HTTP %d", statusCode)
+		},
+	)
+}
+
+// checkEndpoint checks the DNS resolution and connection of the endpoint and reports whether its host resolved
+func (d *doctor) checkEndpoint(host, address string, https bool, failure string) bool {
+	dnsStatus := d.check(
+		"DNS "+host, func() (string, string) {
+			addresses, err := d.diagnosticsWrapper.LookupHost(host)
+			if err != nil {
+				return failure, err.Error()
+			}
+			return doctorPassed, strings.Join(addresses, ", ")
+		},
+	)
+	if !https {
+		d.check(
+			"TCP "+address, func() (string, string) {
+				if err := d.diagnosticsWrapper.Dial(address); err != nil {
+					return failure, err.Error()
+				}
+				return doctorPassed, "Connected"
+			},
+		)
+		return dnsStatus == doctorPassed
+	}
+	d.check(
+		"TLS "+address, func() (string, string) {
+			details, err := d.diagnosticsWrapper.HandshakeTLS(address, host)
+			if err != nil {
+				return failure, err.Error()
+			}
+			d.report.Certificates[address] = details
+			summary := describeTLS(details)
+			if details.VerifyError == "" {
+				return doctorPassed, summary
+			}
+			if viper.GetBool(commonParams.InsecureFlag) {
+				return doctorWarning, summary + ", ignored because of --insecure"
+			}
+			return doctorFailed, summary
+		},
+	)
+	return dnsStatus == doctorPassed
+}
+
+// doctorProxiedEndpoint is an endpoint with the proxy it resolves to, nil when it is reached directly
+type doctorProxiedEndpoint struct {
+	uri     string
+	address string
+	route   *wrappers.ProxyRoute
+	err     error
+}
+
+// checkProxy reports the proxy the endpoint resolves to and opens an authenticated tunnel to it through that proxy
+func (d *doctor) checkProxy(endpoint doctorProxiedEndpoint) {
+	if endpoint.err != nil || endpoint.route == nil {
+		d.check(
+			"Proxy for "+endpoint.address, func() (string, string) {
+				if endpoint.err != nil {
+					return doctorFailed, endpoint.err.Error()
+				}
+				return doctorSkipped, "Direct connection"
+			},
+		)
+		return
+	}
+	route := endpoint.route
+	d.check(
+		fmt.Sprintf("Proxy %s for %s (%s)", route.URL.Host, endpoint.address, route.Type), func() (string, string) {
+			if err := d.diagnosticsWrapper.ConnectThroughProxy(endpoint.uri); err != nil {
+				return doctorFailed, err.Error()
+			}
+			if route.Type == ntlmProxyType && viper.GetString(commonParams.ProxyDomainKey) == "" {
+				return doctorWarning, "Tunnel established, but no NTLM domain is configured"
+			}
+			return doctorPassed, "Tunnel established"
+		},
+	)
+}
+
+func (d *doctor) checkAuthentication() {
+	d.check(
+		"Access token", func() (string, string) {
+			token, err := d.diagnosticsWrapper.GetAccessToken()
+			if err != nil {
+				return doctorFailed, err.Error()
+			}
+			d.secrets = append(d.secrets, token)
+			return doctorPassed, "Token acquired"
+		},
+	)
+	d.check(
+		"Authenticated API call", func() (string, string) {
+			projects, errorModel, err := d.projectsWrapper.Get(map[string]string{commonParams.LimitQueryParam: "1"})
+			if err != nil {
+				return doctorFailed, err.Error()
+			}
+			if errorModel != nil {
+				return doctorFailed, fmt.Sprintf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+			}
+			total := uint(0)
+			if projects != nil {
+				total = projects.TotalCount
+			}
+			return doctorPassed, fmt.Sprintf("%d projects visible", total)
+		},
+	)
+}
+
+func (d *doctor) checkContainerEngine(engine string) {
+	d.check(
+		"Container engine "+engine, func() (string, string) {
+			version, err := d.diagnosticsWrapper.GetContainerEngineVersion(engine)
+			if err != nil {
+				return doctorWarning, err.Error() + ", only needed by scan kics-realtime"
+			}
+			return doctorPassed, "Server version " + version
+		},
+	)
+}
+
+// splitDoctorURI returns the host and port of the URI, and whether it uses TLS
+func splitDoctorURI(uri string) (host, port string, https bool, err error) {
+	parsed, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return "", "", false, err
+	}
+	if parsed.Hostname() == "" {
+		return "", "", false, errors.Errorf("Invalid URI %q", uri)
+	}
+	https = parsed.Scheme == httpsScheme
+	port = parsed.Port()
+	if port == "" {
+		port = defaultHTTPPort
+		if https {
+			port = defaultHTTPSPort
+		}
+	}
+	return parsed.Hostname(), port, https, nil
+}
+
+func describeTLS(details *wrappers.TLSDetails) string {
+	summary := fmt.Sprintf("%s, %d certificates", details.Version, len(details.Certificates))
+	if len(details.Certificates) > 0 {
+		leaf := details.Certificates[0]
+		summary += fmt.Sprintf(", %s issued by %s expires %s", leaf.Subject, leaf.Issuer, leaf.NotAfter.Format(doctorDateFormat))
+	}
+	if details.VerifyError != "" {
+		summary += ", untrusted: " + details.VerifyError
+	}
+	return summary
+}
+
+func writeDoctorReport(path string, report *doctorReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, doctorReportPermissions)
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	hookSignatureHeader = "X-Checkmarx-Signature"
+	hookEventHeader     = "X-Checkmarx-Event"
+	hookSignatureEnv    = "CX_HOOK_SIGNATURE"
+	hookEventEnv        = "CX_HOOK_EVENT"
+	hookSignaturePrefix = "sha256="
+	hookRetryDelay      = time.Second
+	hookRetriesDefault  = 3
+)
+
+// scanHooks notifies URLs and local commands about the scan lifecycle events. A nil *scanHooks does nothing,
+// so callers do not need to check if hooks were configured.
+type scanHooks struct {
+	urls           []string
+	commands       []string
+	events         map[string]bool
+	secret         string
+	retries        int
+	hooksWrapper   wrappers.HooksWrapper
+	scansWrapper   wrappers.ScansWrapper
+	resultsWrapper wrappers.ResultsWrapper
+}
+
+func addScanHookFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSlice(commonParams.HookURLFlag, []string{}, commonParams.HookURLFlagUsage)
+	cmd.PersistentFlags().StringSlice(commonParams.HookCommandFlag, []string{}, commonParams.HookCommandFlagUsage)
+	cmd.PersistentFlags().StringSlice(
+		commonParams.HookEventsFlag,
+		[]string{},
+		fmt.Sprintf(commonParams.HookEventsFlagUsage, strings.Join(wrappers.HookEvents, ",")),
+	)
+	cmd.PersistentFlags().String(commonParams.HookSecretFlag, "", commonParams.HookSecretFlagUsage)
+	cmd.PersistentFlags().Int(commonParams.HookRetriesFlag, hookRetriesDefault, commonParams.HookRetriesFlagUsage)
+}
+
+func newScanHooks(
+	cmd *cobra.Command,
+	hooksWrapper wrappers.HooksWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+) (*scanHooks, error) {
+	urls, _ := cmd.Flags().GetStringSlice(commonParams.HookURLFlag)
+	commands, _ := cmd.Flags().GetStringSlice(commonParams.HookCommandFlag)
+	if len(urls) == 0 && len(commands) == 0 {
+		return nil, nil
+	}
+	retries, _ := cmd.Flags().GetInt(commonParams.HookRetriesFlag)
+	if retries < 0 {
+		return nil, errors.Errorf("--%s should be equal or higher than 0", commonParams.HookRetriesFlag)
+	}
+	events := make(map[string]bool)
+	userEvents, _ := cmd.Flags().GetStringSlice(commonParams.HookEventsFlag)
+	if len(userEvents) == 0 {
+		userEvents = wrappers.HookEvents
+	}
+	for _, event := range userEvents {
+		event = strings.ToLower(strings.TrimSpace(event))
+		if !isHookEvent(event) {
+			return nil, errors.Errorf("unknown hook event: %s", event)
+		}
+		events[event] = true
+	}
+	secret, _ := cmd.Flags().GetString(commonParams.HookSecretFlag)
+	if secret == "" {
+		secret = viper.GetString(commonParams.HookSecretKey)
+	}
+	return &scanHooks{
+		urls:           urls,
+		commands:       commands,
+		events:         events,
+		secret:         secret,
+		retries:        retries,
+		hooksWrapper:   hooksWrapper,
+		scansWrapper:   scansWrapper,
+		resultsWrapper: resultsWrapper,
+	}, nil
+}
+
+// fire delivers the event to every hook. Hook failures are logged but never fail the scan.
+func (h *scanHooks) fire(event string, scan *wrappers.ScanResponseModel, message string) {
+	if h == nil || scan == nil || !h.events[event] {
+		return
+	}
+	payload := wrappers.HookPayload{
+		Event:     event,
+		Timestamp: time.Now().UTC(),
+		Scan:      scan,
+		Message:   message,
+	}
+	if event == wrappers.HookScanCompleted || event == wrappers.HookScanThresholdFailed {
+		payload.Summary = h.summary(scan.ID)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Failed to serialize the %s hook payload: %v\n", event, err)
+		return
+	}
+	headers := map[string]string{hookEventHeader: event}
+	env := []string{fmt.Sprintf("%s=%s", hookEventEnv, event)}
+	if h.secret != "" {
+		signature := signHookPayload(h.secret, body)
+		headers[hookSignatureHeader] = signature
+		env = append(env, fmt.Sprintf("%s=%s", hookSignatureEnv, signature))
+	}
+	for _, url := range h.urls {
+		url := url
+		h.deliver(event, url, func() error {
+			return h.hooksWrapper.Post(url, body, headers)
+		})
+	}
+	for _, command := range h.commands {
+		command := command
+		h.deliver(event, command, func() error {
+			return h.hooksWrapper.Run(command, body, env)
+		})
+	}
+}
+
+func (h *scanHooks) deliver(event, target string, send func() error) {
+	var err error
+	for attempt := 0; attempt <= h.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * hookRetryDelay)
+		}
+		err = send()
+		if err == nil {
+			logger.PrintIfVerbose(fmt.Sprintf("Hook %s notified about %s", target, event))
+			return
+		}
+		logger.PrintIfVerbose(fmt.Sprintf("Hook %s attempt %d failed: %v", target, attempt+1, err))
+	}
+	log.Printf("Hook %s failed for event %s: %v\n", target, event, err)
+}
+
+func (h *scanHooks) summary(scanID string) *wrappers.ResultSummary {
+	results, err := ReadResults(h.resultsWrapper, scanID, make(map[string]string))
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Hook payload sent without summary: %v", err))
+		return nil
+	}
+	summary, err := SummaryReport(h.scansWrapper, results, scanID)
+	if err != nil {
+		logger.PrintIfVerbose(fmt.Sprintf("Hook payload sent without summary: %v", err))
+		return nil
+	}
+	return summary
+}
+
+func isHookEvent(event string) bool {
+	for _, hookEvent := range wrappers.HookEvents {
+		if hookEvent == event {
+			return true
+		}
+	}
+	return false
+}
+
+// signHookPayload computes the HMAC-SHA256 signature receivers use to check the payload came from the CLI
+func signHookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// scanStatusHookEvent maps the final status of a scan to the hook event
+func scanStatusHookEvent(status wrappers.ScanStatus) string {
+	switch status {
+	case wrappers.ScanCompleted:
+		return wrappers.HookScanCompleted
+	case wrappers.ScanPartial:
+		return wrappers.HookScanPartial
+	default:
+		return wrappers.HookScanFailed
+	}
+}
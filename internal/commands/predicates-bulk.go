@@ -0,0 +1,509 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedTriageBulk     = "Failed applying the predicates"
+	triageStatusUpdated  = "Updated"
+	triageStatusFailed   = "Failed"
+	triageStatusDryRun   = "Dry run"
+	triageJSONExtension  = ".json"
+	triageFilePermission = 0644
+)
+
+// triageFileColumns are the columns of a triage CSV file, named like the PredicateRequest JSON fields
+var triageFileColumns = []string{"similarityId", "projectId", "scannerType", "state", "severity", "comment"}
+
+type triageItemView struct {
+	SimilarityID string `format:"name:Similarity ID"`
+	ProjectID    string `format:"name:Project ID"`
+	ScannerType  string `format:"name:Scan Type"`
+	State        string
+	Severity     string
+	Comment      string
+	Status       string
+	Error        string `json:"Error,omitempty"`
+}
+
+func triageBulkSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageBulkCmd := &cobra.Command{
+		Use:   "bulk",
+		Short: "Update the state, severity or comment of several issues",
+		Long: "The bulk command triages every issue listed in a CSV or JSON file, " +
+			"or every issue of a scan matching the given filters.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage bulk --file decisions.csv --project-id <ProjectID> --scan-type <SAST|KICS>
+			$ cx triage bulk --scan-id <ScanID> --filter "severity=LOW" --file-glob "test/**" --state NOT_EXPLOITABLE
+			$ cx triage bulk --file decisions.json --dry-run
+		`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				CSV files must start with a header row using the columns: similarityId, projectId, scannerType,
+				state, severity and comment. JSON files are an array of objects with the same fields.
+				Items that fail are written to the retry file, which can be given back to --file.
+			`,
+			),
+		},
+		RunE: runTriageBulk(resultsPredicatesWrapper, resultsWrapper, scansWrapper),
+	}
+
+	triageBulkCmd.PersistentFlags().String(params.TriageFileFlag, "", params.TriageFileFlagUsage)
+	triageBulkCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID, used when the file does not have it.")
+	triageBulkCmd.PersistentFlags().String(params.ScanTypeFlag, "", "Scan Type, used when the file does not have it.")
+	triageBulkCmd.PersistentFlags().String(params.ScanIDFlag, "", "Scan ID of the results to triage.")
+	triageBulkCmd.PersistentFlags().StringSlice(params.FilterFlag, []string{}, filterResultsListFlagUsage)
+	triageBulkCmd.PersistentFlags().String(params.FileGlobFlag, "", params.FileGlobFlagUsage)
+	triageBulkCmd.PersistentFlags().String(params.StateFlag, "", "State, used with --scan-id")
+	triageBulkCmd.PersistentFlags().String(params.SeverityFlag, "", "Severity, used with --scan-id")
+	triageBulkCmd.PersistentFlags().String(params.CommentFlag, "", "Optional comment, used with --scan-id")
+	addTriageApplyFlags(triageBulkCmd)
+	addFormatFlag(triageBulkCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+
+	return triageBulkCmd
+}
+
+func addTriageApplyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(params.DryRunFlag, false, params.DryRunFlagUsage)
+	cmd.PersistentFlags().String(params.RetryFileFlag, params.RetryFileDefault, params.RetryFileFlagUsage)
+	addConcurrencyFlags(cmd)
+}
+
+func addConcurrencyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Int(params.ConcurrencyFlag, params.ConcurrencyDefault, params.ConcurrencyFlagUsage)
+	cmd.PersistentFlags().Int(params.RateLimitFlag, params.RateLimitDefault, params.RateLimitFlagUsage)
+}
+
+func getConcurrencyFlags(cmd *cobra.Command) (concurrency, rateLimit int, err error) {
+	concurrency, _ = cmd.Flags().GetInt(params.ConcurrencyFlag)
+	if concurrency < 1 {
+		return 0, 0, errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+	}
+	rateLimit, _ = cmd.Flags().GetInt(params.RateLimitFlag)
+	if rateLimit < 0 {
+		return 0, 0, errors.Errorf("--%s should be equal or higher than 0", params.RateLimitFlag)
+	}
+	return concurrency, rateLimit, nil
+}
+
+// runConcurrently calls work for every index from 0 to count using a pool of workers. When rateLimit is higher
+// than 0 the calls are spread so that no more than rateLimit calls start each second.
+func runConcurrently(count, concurrency, rateLimit int, work func(i int)) {
+	var throttle <-chan time.Time
+	if rateLimit > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rateLimit))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if throttle != nil {
+					<-throttle
+				}
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func runTriageBulk(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		filePath, _ := cmd.Flags().GetString(params.TriageFileFlag)
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		if (filePath == "") == (scanID == "") {
+			return errors.Errorf(
+				"%s: Please provide either --%s or --%s",
+				failedTriageBulk,
+				params.TriageFileFlag,
+				params.ScanIDFlag,
+			)
+		}
+
+		var items []*wrappers.PredicateRequest
+		var err error
+		if filePath != "" {
+			projectID, _ := cmd.Flags().GetString(params.ProjectIDFlag)
+			scanType, _ := cmd.Flags().GetString(params.ScanTypeFlag)
+			items, err = readTriageFile(filePath, projectID, scanType)
+		} else {
+			items, err = triageItemsFromResults(cmd, resultsWrapper, scansWrapper, scanID)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedTriageBulk)
+		}
+		_, err = applyTriageItems(cmd, resultsPredicatesWrapper, items)
+		return err
+	}
+}
+
+// applyTriageItems sends the predicates according to the dry run, concurrency and rate limit flags, prints the
+// report and writes the items that failed to the retry file. The views are returned even when items failed.
+func applyTriageItems(
+	cmd *cobra.Command,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	items []*wrappers.PredicateRequest,
+) ([]*triageItemView, error) {
+	dryRun, _ := cmd.Flags().GetBool(params.DryRunFlag)
+	concurrency, rateLimit, err := getConcurrencyFlags(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	views := applyPredicates(resultsPredicatesWrapper, items, dryRun, concurrency, rateLimit)
+	err = printByFormat(cmd, views)
+	if err != nil {
+		return views, err
+	}
+
+	var failedItems []*wrappers.PredicateRequest
+	for i, view := range views {
+		if view.Status == triageStatusFailed {
+			failedItems = append(failedItems, items[i])
+		}
+	}
+	if len(failedItems) == 0 {
+		return views, nil
+	}
+	retryFile, _ := cmd.Flags().GetString(params.RetryFileFlag)
+	err = writeTriageFile(retryFile, failedItems)
+	if err != nil {
+		return views, errors.Wrapf(err, "%s: failed writing the retry file", failedTriageBulk)
+	}
+	return views, errors.Errorf(
+		"%s: %d of %d items failed, they were written to %s",
+		failedTriageBulk,
+		len(failedItems),
+		len(items),
+		retryFile,
+	)
+}
+
+// applyPredicates updates every item concurrently, the views keep the order of the items
+func applyPredicates(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	items []*wrappers.PredicateRequest,
+	dryRun bool,
+	concurrency, rateLimit int,
+) []*triageItemView {
+	views := make([]*triageItemView, len(items))
+	if dryRun {
+		for i, item := range items {
+			views[i] = toTriageItemView(item, triageStatusDryRun, nil)
+		}
+		return views
+	}
+
+	runConcurrently(
+		len(items), concurrency, rateLimit, func(i int) {
+			views[i] = applyPredicate(resultsPredicatesWrapper, items[i])
+		},
+	)
+	return views
+}
+
+func applyPredicate(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	item *wrappers.PredicateRequest,
+) *triageItemView {
+	errorModel, err := resultsPredicatesWrapper.PredicateSeverityAndState(item)
+	if err == nil && errorModel != nil {
+		err = errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	if err != nil {
+		return toTriageItemView(item, triageStatusFailed, err)
+	}
+	return toTriageItemView(item, triageStatusUpdated, nil)
+}
+
+func toTriageItemView(item *wrappers.PredicateRequest, status string, err error) *triageItemView {
+	view := &triageItemView{
+		SimilarityID: item.SimilarityID,
+		ProjectID:    item.ProjectID,
+		ScannerType:  item.ScannerType,
+		State:        item.State,
+		Severity:     item.Severity,
+		Comment:      item.Comment,
+		Status:       status,
+	}
+	if err != nil {
+		view.Error = err.Error()
+	}
+	return view
+}
+
+// readTriageFile reads the items of a CSV or JSON triage file. Missing project IDs and scan types are filled
+// with the given defaults.
+func readTriageFile(filePath, defaultProjectID, defaultScanType string) ([]*wrappers.PredicateRequest, error) {
+	var items []*wrappers.PredicateRequest
+	var err error
+	if strings.EqualFold(filepath.Ext(filePath), triageJSONExtension) {
+		items, err = readTriageJSONFile(filePath)
+	} else {
+		items, err = readTriageCSVFile(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if item.ProjectID == "" {
+			item.ProjectID = defaultProjectID
+		}
+		if item.ScannerType == "" {
+			item.ScannerType = defaultScanType
+		}
+		var missing []string
+		for j, value := range []string{item.SimilarityID, item.ProjectID, item.ScannerType, item.State, item.Severity} {
+			if strings.TrimSpace(value) == "" {
+				missing = append(missing, triageFileColumns[j])
+			}
+		}
+		if len(missing) > 0 {
+			return nil, errors.Errorf("item %d of %s is missing %s", i+1, filePath, strings.Join(missing, ", "))
+		}
+	}
+	return items, nil
+}
+
+func readTriageJSONFile(filePath string) ([]*wrappers.PredicateRequest, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var items []*wrappers.PredicateRequest
+	err = json.Unmarshal(content, &items)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing %s", filePath)
+	}
+	return items, nil
+}
+
+func readTriageCSVFile(filePath string) ([]*wrappers.PredicateRequest, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing %s", filePath)
+	}
+	if len(rows) == 0 {
+		return nil, errors.Errorf("%s is empty", filePath)
+	}
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		column := ""
+		for _, triageColumn := range triageFileColumns {
+			if strings.EqualFold(strings.TrimSpace(name), triageColumn) {
+				column = triageColumn
+			}
+		}
+		if column == "" {
+			return nil, errors.Errorf("unknown column in %s: %s", filePath, name)
+		}
+		columns[column] = i
+	}
+	value := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	items := make([]*wrappers.PredicateRequest, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		items = append(
+			items, &wrappers.PredicateRequest{
+				SimilarityID: value(row, "similarityId"),
+				ProjectID:    value(row, "projectId"),
+				ScannerType:  value(row, "scannerType"),
+				State:        value(row, "state"),
+				Severity:     value(row, "severity"),
+				Comment:      value(row, "comment"),
+			},
+		)
+	}
+	return items, nil
+}
+
+// writeTriageFile writes the items as JSON when the file has a .json extension, otherwise as CSV
+func writeTriageFile(filePath string, items []*wrappers.PredicateRequest) error {
+	if strings.EqualFold(filepath.Ext(filePath), triageJSONExtension) {
+		content, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filePath, content, triageFilePermission)
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	err = writer.Write(triageFileColumns)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		err = writer.Write(
+			[]string{item.SimilarityID, item.ProjectID, item.ScannerType, item.State, item.Severity, item.Comment},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// triageItemsFromResults builds an item for every SAST and KICS result of the scan matching the filters
+func triageItemsFromResults(
+	cmd *cobra.Command,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	scanID string,
+) ([]*wrappers.PredicateRequest, error) {
+	state, _ := cmd.Flags().GetString(params.StateFlag)
+	severity, _ := cmd.Flags().GetString(params.SeverityFlag)
+	comment, _ := cmd.Flags().GetString(params.CommentFlag)
+	if state == "" && severity == "" {
+		return nil, errors.Errorf("Please provide --%s or --%s", params.StateFlag, params.SeverityFlag)
+	}
+	var fileGlob *regexp.Regexp
+	if glob, _ := cmd.Flags().GetString(params.FileGlobFlag); glob != "" {
+		fileGlob = fileGlobRegexp(glob)
+	}
+	scan, errorModel, err := scansWrapper.GetByID(scanID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGetting)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(ErrorCodeFormat, failedGetting, errorModel.Code, errorModel.Message)
+	}
+	filters, err := getFilters(cmd)
+	if err != nil {
+		return nil, err
+	}
+	results, err := ReadResults(resultsWrapper, scanID, filters)
+	if err != nil {
+		return nil, err
+	}
+	var items []*wrappers.PredicateRequest
+	if results == nil {
+		return items, nil
+	}
+	for _, result := range results.Results {
+		if !isTriageableResult(result) {
+			continue
+		}
+		if fileGlob != nil && !fileGlob.MatchString(normalizeResultPath(resultFileName(result))) {
+			continue
+		}
+		item := &wrappers.PredicateRequest{
+			SimilarityID: result.SimilarityID,
+			ProjectID:    scan.ProjectID,
+			ScannerType:  strings.ToLower(result.Type),
+			State:        state,
+			Severity:     severity,
+			Comment:      comment,
+		}
+		if item.State == "" {
+			item.State = result.State
+		}
+		if item.Severity == "" {
+			item.Severity = result.Severity
+		}
+		items = append(items, item)
+	}
+	log.Printf("%d results of scan %s matched the filters\n", len(items), scanID)
+	return items, nil
+}
+
+// isTriageableResult reports if the predicates API can triage the result, only SAST and KICS results are supported
+func isTriageableResult(result *wrappers.ScanResult) bool {
+	if result.SimilarityID == "" {
+		return false
+	}
+	return strings.EqualFold(result.Type, params.SastType) || strings.EqualFold(result.Type, params.KicsType)
+}
+
+// resultFileName returns the file of the result, the first node for SAST and the file name for KICS
+func resultFileName(result *wrappers.ScanResult) string {
+	if len(result.ScanResultData.Nodes) > 0 && result.ScanResultData.Nodes[0] != nil {
+		return result.ScanResultData.Nodes[0].FileName
+	}
+	return result.ScanResultData.Filename
+}
+
+func normalizeResultPath(fileName string) string {
+	return strings.TrimPrefix(filepath.ToSlash(fileName), "/")
+}
+
+// fileGlobRegexp converts a file glob to a regular expression. '*' and '?' do not match '/', '**' matches any
+// number of directories and a pattern ending with '/' matches everything under the directory.
+func fileGlobRegexp(glob string) *regexp.Regexp {
+	glob = normalizeResultPath(glob)
+	if strings.HasSuffix(glob, "/") {
+		glob += "**"
+	}
+	var expression strings.Builder
+	expression.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			expression.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			expression.WriteString("[^/]*")
+		case glob[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(glob[i])))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String())
+}
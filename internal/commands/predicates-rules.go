@@ -0,0 +1,374 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedApplyingRules      = "Failed applying the triage rules"
+	triageStatusSkipped      = "Skipped"
+	triageAuditLogPermission = 0644
+	cwePrefix                = "CWE-"
+)
+
+// triageAuditEntry is a line of the audit log, one JSON object for every change made or previewed by a rule
+type triageAuditEntry struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Rule             string    `json:"rule"`
+	ScanID           string    `json:"scanId"`
+	ProjectID        string    `json:"projectId"`
+	SimilarityID     string    `json:"similarityId"`
+	Engine           string    `json:"engine"`
+	QueryName        string    `json:"queryName,omitempty"`
+	FileName         string    `json:"fileName,omitempty"`
+	PreviousState    string    `json:"previousState"`
+	State            string    `json:"state"`
+	PreviousSeverity string    `json:"previousSeverity"`
+	Severity         string    `json:"severity"`
+	Comment          string    `json:"comment,omitempty"`
+	DryRun           bool      `json:"dryRun"`
+	Status           string    `json:"status"`
+	Error            string    `json:"error,omitempty"`
+}
+
+// triageRuleMatcher is a rule with its globs compiled
+type triageRuleMatcher struct {
+	rule        *configuration.TriageRule
+	queryName   *regexp.Regexp
+	path        *regexp.Regexp
+	packageName *regexp.Regexp
+}
+
+// triageRuleMatch is a result matched by a rule and the predicate the rule produces for it
+type triageRuleMatch struct {
+	rule   *configuration.TriageRule
+	result *wrappers.ScanResult
+	item   *wrappers.PredicateRequest
+}
+
+func triageApplyRulesSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageApplyRulesCmd := &cobra.Command{
+		Use:   "apply-rules",
+		Short: "Triage the results of a scan using rules",
+		Long: "The apply-rules command sets the state, severity or comment of every result of the scan " +
+			"matching a rule of the rules file.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage apply-rules --scan-id <ScanID> --rules rules.yaml
+			$ cx triage apply-rules --scan-id <ScanID> --rules rules.yaml --dry-run --audit-log audit.jsonl
+		`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				rules:
+				  - name: kics-tests
+				    match:
+				      engine: kics
+				      pathGlob: test/**
+				    set:
+				      state: NOT_EXPLOITABLE
+				      comment: Test infrastructure
+				  - name: admin-xss
+				    match:
+				      engine: sast
+				      queryName: Stored_XSS
+				      pathGlob: src/admin/**
+				    set:
+				      severity: MEDIUM
+
+				Rules can also match on cwe, packageName and severities. The first matching rule wins and
+				every change is appended to the audit log as a JSON line.
+			`,
+			),
+		},
+		RunE: runTriageApplyRules(resultsPredicatesWrapper, resultsWrapper, scansWrapper),
+	}
+
+	triageApplyRulesCmd.PersistentFlags().String(params.ScanIDFlag, "", "Scan ID of the results to triage.")
+	triageApplyRulesCmd.PersistentFlags().String(params.TriageRulesFlag, "", params.TriageRulesFlagUsage)
+	triageApplyRulesCmd.PersistentFlags().String(
+		params.AuditLogFlag,
+		params.AuditLogDefault,
+		params.AuditLogFlagUsage,
+	)
+	addTriageApplyFlags(triageApplyRulesCmd)
+	addFormatFlag(triageApplyRulesCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+
+	markFlagAsRequired(triageApplyRulesCmd, params.ScanIDFlag)
+	markFlagAsRequired(triageApplyRulesCmd, params.TriageRulesFlag)
+
+	return triageApplyRulesCmd
+}
+
+func runTriageApplyRules(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		rulesPath, _ := cmd.Flags().GetString(params.TriageRulesFlag)
+		auditLog, _ := cmd.Flags().GetString(params.AuditLogFlag)
+		dryRun, _ := cmd.Flags().GetBool(params.DryRunFlag)
+
+		rules, err := configuration.LoadTriageRules(rulesPath)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingRules)
+		}
+		scan, errorModel, err := scansWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGetting)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedGetting, errorModel.Code, errorModel.Message)
+		}
+		results, err := ReadResults(resultsWrapper, scanID, make(map[string]string))
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingRules)
+		}
+
+		matches, err := matchTriageRules(resultsPredicatesWrapper, newTriageRuleMatchers(rules), results, scan.ProjectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedApplyingRules)
+		}
+		var items []*wrappers.PredicateRequest
+		for _, match := range matches {
+			if match.item != nil {
+				items = append(items, match.item)
+			}
+		}
+		log.Printf("%d results of scan %s matched the rules and need changes\n", len(items), scanID)
+
+		views, applyErr := applyTriageItems(cmd, resultsPredicatesWrapper, items)
+		if views == nil && applyErr != nil {
+			return applyErr
+		}
+		audit := make([]*triageAuditEntry, 0, len(matches))
+		applied := 0
+		for _, match := range matches {
+			var view *triageItemView
+			if match.item != nil {
+				view = views[applied]
+				applied++
+			}
+			audit = append(audit, toTriageAuditEntry(match, scanID, scan.ProjectID, dryRun, view))
+		}
+		err = appendTriageAuditLog(auditLog, audit)
+		if err != nil {
+			return errors.Wrapf(err, "%s: failed writing the audit log", failedApplyingRules)
+		}
+		return applyErr
+	}
+}
+
+func newTriageRuleMatchers(rules *configuration.TriageRules) []*triageRuleMatcher {
+	matchers := make([]*triageRuleMatcher, len(rules.Rules))
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		matchers[i] = &triageRuleMatcher{
+			rule:        rule,
+			queryName:   optionalGlobRegexp(rule.Match.QueryName, true),
+			path:        optionalGlobRegexp(rule.Match.PathGlob, false),
+			packageName: optionalGlobRegexp(rule.Match.PackageName, true),
+		}
+	}
+	return matchers
+}
+
+func optionalGlobRegexp(glob string, ignoreCase bool) *regexp.Regexp {
+	if glob == "" {
+		return nil
+	}
+	expression := fileGlobRegexp(glob)
+	if ignoreCase {
+		return regexp.MustCompile("(?i)" + expression.String())
+	}
+	return expression
+}
+
+// matchTriageRules returns the results matched by a rule. The first matching rule wins. Results the rule does not
+// change are left out, and results the predicates API cannot triage are returned without an item.
+func matchTriageRules(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	matchers []*triageRuleMatcher,
+	results *wrappers.ScanResultsCollection,
+	projectID string,
+) ([]*triageRuleMatch, error) {
+	var matches []*triageRuleMatch
+	if results == nil {
+		return matches, nil
+	}
+	for _, result := range results.Results {
+		for _, matcher := range matchers {
+			if !matcher.matches(result) {
+				continue
+			}
+			match := &triageRuleMatch{rule: matcher.rule, result: result}
+			if isTriageableResult(result) {
+				match.item = triageRuleItem(matcher.rule, result, projectID)
+				changed, err := triageItemChanges(resultsPredicatesWrapper, match.item, result, projectID)
+				if err != nil {
+					return nil, err
+				}
+				if !changed {
+					break
+				}
+			}
+			matches = append(matches, match)
+			break
+		}
+	}
+	return matches, nil
+}
+
+func (m *triageRuleMatcher) matches(result *wrappers.ScanResult) bool {
+	match := m.rule.Match
+	if match.Engine != "" && !strings.EqualFold(match.Engine, result.Type) {
+		return false
+	}
+	if len(match.Severities) > 0 && !utils.ContainsFold(match.Severities, result.Severity) {
+		return false
+	}
+	if match.Cwe != "" && strings.TrimPrefix(strings.ToUpper(match.Cwe), cwePrefix) != resultCwe(result) {
+		return false
+	}
+	if m.queryName != nil && !m.queryName.MatchString(result.ScanResultData.QueryName) {
+		return false
+	}
+	if m.path != nil && !m.path.MatchString(normalizeResultPath(resultFileName(result))) {
+		return false
+	}
+	if m.packageName != nil && !m.packageName.MatchString(result.ScanResultData.PackageIdentifier) {
+		return false
+	}
+	return true
+}
+
+// triageRuleItem builds the predicate the rule sets on the result
+func triageRuleItem(
+	rule *configuration.TriageRule,
+	result *wrappers.ScanResult,
+	projectID string,
+) *wrappers.PredicateRequest {
+	item := &wrappers.PredicateRequest{
+		SimilarityID: result.SimilarityID,
+		ProjectID:    projectID,
+		ScannerType:  strings.ToLower(result.Type),
+		State:        result.State,
+		Severity:     strings.ToUpper(result.Severity),
+		Comment:      rule.Set.Comment,
+	}
+	if rule.Set.State != "" {
+		item.State = strings.ToUpper(rule.Set.State)
+	}
+	if rule.Set.Severity != "" {
+		item.Severity = strings.ToUpper(rule.Set.Severity)
+	}
+	return item
+}
+
+// triageItemChanges reports whether posting the item changes the result. When the state and severity already match,
+// the result only changes if its latest predicate has another comment, or if it was never triaged and the rule
+// sets a comment.
+func triageItemChanges(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	item *wrappers.PredicateRequest,
+	result *wrappers.ScanResult,
+	projectID string,
+) (bool, error) {
+	if !strings.EqualFold(item.State, result.State) || !strings.EqualFold(item.Severity, result.Severity) {
+		return true, nil
+	}
+	latest, err := latestPredicate(resultsPredicatesWrapper, projectID, result)
+	if err != nil {
+		return false, err
+	}
+	if latest == nil {
+		return item.Comment != "", nil
+	}
+	return latest.Comment != item.Comment, nil
+}
+
+func resultCwe(result *wrappers.ScanResult) string {
+	if result.VulnerabilityDetails.CweID == nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToUpper(fmt.Sprint(result.VulnerabilityDetails.CweID)), cwePrefix)
+}
+
+func toTriageAuditEntry(
+	match *triageRuleMatch,
+	scanID, projectID string,
+	dryRun bool,
+	view *triageItemView,
+) *triageAuditEntry {
+	entry := &triageAuditEntry{
+		Timestamp:        time.Now().UTC(),
+		Rule:             match.rule.Name,
+		ScanID:           scanID,
+		ProjectID:        projectID,
+		SimilarityID:     match.result.SimilarityID,
+		Engine:           strings.ToLower(match.result.Type),
+		QueryName:        match.result.ScanResultData.QueryName,
+		FileName:         resultFileName(match.result),
+		PreviousState:    match.result.State,
+		State:            match.result.State,
+		PreviousSeverity: match.result.Severity,
+		Severity:         match.result.Severity,
+		DryRun:           dryRun,
+		Status:           triageStatusSkipped,
+	}
+	if match.item == nil {
+		entry.Error = fmt.Sprintf("%s results cannot be triaged", entry.Engine)
+		return entry
+	}
+	entry.State = match.item.State
+	entry.Severity = match.item.Severity
+	entry.Comment = match.item.Comment
+	if view != nil {
+		entry.Status = view.Status
+		entry.Error = view.Error
+	}
+	return entry
+}
+
+// appendTriageAuditLog appends the entries to the audit log as JSON lines
+func appendTriageAuditLog(auditLog string, entries []*triageAuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	file, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, triageAuditLogPermission)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	for _, entry := range entries {
+		err = encoder.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("%d changes written to the audit log %s\n", len(entries), auditLog)
+	return nil
+}
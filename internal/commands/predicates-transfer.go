@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"log"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedTriageExport    = "Failed exporting the predicates"
+	failedTriageImport    = "Failed importing the predicates"
+	triageExportDefault   = "triage-export.json"
+	triageExportFileUsage = "File receiving the predicates, CSV or JSON depending on the extension"
+	triageImportFileUsage = "File created by 'triage export'"
+)
+
+func triageExportSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageExportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the triage decisions of a project",
+		Long: "The export command writes the latest state, severity and comment of every issue " +
+			"found in the latest scan of the project.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage export --project-id <ProjectID> --file triage.json
+		`,
+		),
+		RunE: runTriageExport(resultsPredicatesWrapper, resultsWrapper, scansWrapper),
+	}
+
+	triageExportCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID.")
+	triageExportCmd.PersistentFlags().String(params.TriageFileFlag, triageExportDefault, triageExportFileUsage)
+	addConcurrencyFlags(triageExportCmd)
+
+	markFlagAsRequired(triageExportCmd, params.ProjectIDFlag)
+
+	return triageExportCmd
+}
+
+func triageImportSubCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) *cobra.Command {
+	triageImportCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import triage decisions into a project",
+		Long: "The import command applies the decisions of an exported file to the issues found in the " +
+			"latest scan of the project. Issues that do not exist in the project are skipped.",
+		Example: heredoc.Doc(
+			`
+			$ cx triage import --project-id <ProjectID> --file triage.json
+			$ cx triage import --project-id <ProjectID> --file triage.json --dry-run
+		`,
+		),
+		RunE: runTriageImport(resultsPredicatesWrapper, resultsWrapper, scansWrapper),
+	}
+
+	triageImportCmd.PersistentFlags().String(params.ProjectIDFlag, "", "Project ID.")
+	triageImportCmd.PersistentFlags().String(params.TriageFileFlag, "", triageImportFileUsage)
+	addTriageApplyFlags(triageImportCmd)
+	addFormatFlag(triageImportCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+
+	markFlagAsRequired(triageImportCmd, params.ProjectIDFlag)
+	markFlagAsRequired(triageImportCmd, params.TriageFileFlag)
+
+	return triageImportCmd
+}
+
+func runTriageExport(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		projectID, _ := cmd.Flags().GetString(params.ProjectIDFlag)
+		filePath, _ := cmd.Flags().GetString(params.TriageFileFlag)
+		concurrency, rateLimit, err := getConcurrencyFlags(cmd)
+		if err != nil {
+			return err
+		}
+		results, err := readLatestProjectResults(resultsWrapper, scansWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedTriageExport)
+		}
+
+		var triageable []*wrappers.ScanResult
+		seen := make(map[string]bool)
+		for _, result := range results.Results {
+			if isTriageableResult(result) && !seen[result.SimilarityID] {
+				seen[result.SimilarityID] = true
+				triageable = append(triageable, result)
+			}
+		}
+		items := make([]*wrappers.PredicateRequest, len(triageable))
+		errs := make([]error, len(triageable))
+		runConcurrently(
+			len(triageable), concurrency, rateLimit, func(i int) {
+				items[i], errs[i] = latestPredicate(resultsPredicatesWrapper, projectID, triageable[i])
+			},
+		)
+		var exported []*wrappers.PredicateRequest
+		for i, item := range items {
+			if errs[i] != nil {
+				return errors.Wrapf(errs[i], "%s", failedTriageExport)
+			}
+			if item != nil {
+				exported = append(exported, item)
+			}
+		}
+		err = writeTriageFile(filePath, exported)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedTriageExport)
+		}
+		log.Printf("Exported %d predicates of project %s to %s\n", len(exported), projectID, filePath)
+		return nil
+	}
+}
+
+func runTriageImport(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		projectID, _ := cmd.Flags().GetString(params.ProjectIDFlag)
+		filePath, _ := cmd.Flags().GetString(params.TriageFileFlag)
+		items, err := readTriageFile(filePath, projectID, "")
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedTriageImport)
+		}
+		results, err := readLatestProjectResults(resultsWrapper, scansWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedTriageImport)
+		}
+
+		scannerTypes := make(map[string]string)
+		for _, result := range results.Results {
+			if isTriageableResult(result) {
+				scannerTypes[result.SimilarityID] = strings.ToLower(result.Type)
+			}
+		}
+		var imported []*wrappers.PredicateRequest
+		for _, item := range items {
+			scannerType, found := scannerTypes[item.SimilarityID]
+			if !found {
+				continue
+			}
+			item.ProjectID = projectID
+			item.ScannerType = scannerType
+			imported = append(imported, item)
+		}
+		log.Printf(
+			"%d of %d predicates match issues of project %s, the others are skipped\n",
+			len(imported),
+			len(items),
+			projectID,
+		)
+		_, err = applyTriageItems(cmd, resultsPredicatesWrapper, imported)
+		return err
+	}
+}
+
+// readLatestProjectResults reads the results of the latest completed scan of the project
+func readLatestProjectResults(
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	projectID string,
+) (*wrappers.ScanResultsCollection, error) {
+	scan, err := getLatestScan(scansWrapper, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if scan == nil {
+		return nil, errors.Errorf("project %s has no completed scans", projectID)
+	}
+	results, err := ReadResults(resultsWrapper, scan.ID, make(map[string]string))
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = &wrappers.ScanResultsCollection{ScanID: scan.ID}
+	}
+	return results, nil
+}
+
+// latestPredicate returns the most recent predicate of the result in the project, or nil if it was never triaged
+func latestPredicate(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	projectID string,
+	result *wrappers.ScanResult,
+) (*wrappers.PredicateRequest, error) {
+	scannerType := strings.ToLower(result.Type)
+	predicates, errorModel, err := resultsPredicatesWrapper.GetAllPredicatesForSimilarityID(
+		result.SimilarityID,
+		projectID,
+		scannerType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	if predicates == nil {
+		return nil, nil
+	}
+	var latest *wrappers.Predicate
+	for i := range predicates.PredicateHistoryPerProject {
+		history := &predicates.PredicateHistoryPerProject[i]
+		if history.ProjectID != "" && history.ProjectID != projectID {
+			continue
+		}
+		for j := range history.Predicates {
+			predicate := &history.Predicates[j]
+			if latest == nil || predicate.CreatedAt.After(latest.CreatedAt) {
+				latest = predicate
+			}
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return &wrappers.PredicateRequest{
+		SimilarityID: result.SimilarityID,
+		ProjectID:    projectID,
+		ScannerType:  scannerType,
+		State:        latest.State,
+		Severity:     latest.Severity,
+		Comment:      latest.Comment,
+	}, nil
+}
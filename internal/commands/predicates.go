@@ -12,7 +12,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewResultsPredicatesCommand(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper) *cobra.Command {
+func NewResultsPredicatesCommand(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+) *cobra.Command {
 	triageCmd := &cobra.Command{
 		Use:   "triage",
 		Short: "Manage results",
@@ -20,13 +24,24 @@ func NewResultsPredicatesCommand(resultsPredicatesWrapper wrappers.ResultsPredic
 	}
 	triageShowCmd := triageShowSubCommand(resultsPredicatesWrapper)
 	triageUpdateCmd := triageUpdateSubCommand(resultsPredicatesWrapper)
+	triageBulkCmd := triageBulkSubCommand(resultsPredicatesWrapper, resultsWrapper, scansWrapper)
+	triageExportCmd := triageExportSubCommand(resultsPredicatesWrapper, resultsWrapper, scansWrapper)
+	triageImportCmd := triageImportSubCommand(resultsPredicatesWrapper, resultsWrapper, scansWrapper)
+	triageApplyRulesCmd := triageApplyRulesSubCommand(resultsPredicatesWrapper, resultsWrapper, scansWrapper)
 
 	addFormatFlagToMultipleCommands(
 		[]*cobra.Command{triageShowCmd},
 		printer.FormatList, printer.FormatTable, printer.FormatJSON,
 	)
 
-	triageCmd.AddCommand(triageShowCmd, triageUpdateCmd)
+	triageCmd.AddCommand(
+		triageShowCmd,
+		triageUpdateCmd,
+		triageBulkCmd,
+		triageExportCmd,
+		triageImportCmd,
+		triageApplyRulesCmd,
+	)
 	return triageCmd
 }
 
@@ -4,8 +4,14 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"gotest.tools/assert"
 )
 
@@ -48,3 +54,230 @@ func TestRunUpdateTriageCommandWithNoInput(t *testing.T) {
 		t,
 		err.Error() == "required flag(s) \"project-id\", \"scan-type\", \"severity\", \"similarity-id\", \"state\" not set")
 }
+
+func TestRunBulkTriageCommandFromCSV(t *testing.T) {
+	execCmdNilAssertion(
+		t,
+		"triage", "bulk",
+		"--file", "data/triage/decisions.csv",
+		"--project-id", "MOCK",
+		"--scan-type", "sast",
+	)
+}
+
+func TestRunBulkTriageCommandFromJSON(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "bulk", "--file", "data/triage/decisions.json", "--format", "json")
+}
+
+func TestRunBulkTriageCommandFromResults(t *testing.T) {
+	execCmdNilAssertion(
+		t,
+		"triage", "bulk",
+		"--scan-id", "MOCK",
+		"--filter", "severity=LOW",
+		"--file-glob", "test/**",
+		"--state", "NOT_EXPLOITABLE",
+		"--rate-limit", "0",
+	)
+}
+
+func TestRunBulkTriageCommandDryRun(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "bulk", "--scan-id", "MOCK", "--severity", "LOW", "--dry-run")
+}
+
+func TestRunBulkTriageCommandWithFailures(t *testing.T) {
+	retryFile := filepath.Join(t.TempDir(), "retry.csv")
+	err := execCmdNotNilAssertion(
+		t,
+		"triage", "bulk",
+		"--file", "data/triage/decisions-fail.csv",
+		"--retry-file", retryFile,
+	)
+	assert.Equal(t, err.Error(), "Failed applying the predicates: 1 of 2 items failed, they were written to "+retryFile)
+	content, readErr := os.ReadFile(retryFile)
+	assert.NilError(t, readErr)
+	assert.Equal(t, string(content), "similarityId,projectId,scannerType,state,severity,comment\nMOCK-fail,MOCK,sast,CONFIRMED,HIGH,\n")
+}
+
+func TestRunBulkTriageCommandMissingColumns(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "bulk", "--file", "data/triage/decisions.csv")
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed applying the predicates: item 1 of data/triage/decisions.csv is missing projectId, scannerType",
+	)
+}
+
+func TestRunBulkTriageCommandUnknownColumn(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "bulk", "--file", "data/triage/decisions-invalid.csv")
+	assert.Assert(t, strings.Contains(err.Error(), "unknown column"), err.Error())
+}
+
+func TestRunBulkTriageCommandWithNoInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "bulk")
+	assert.Equal(t, err.Error(), "Failed applying the predicates: Please provide either --file or --scan-id")
+}
+
+func TestRunBulkTriageCommandFromResultsWithNoState(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "bulk", "--scan-id", "MOCK")
+	assert.Equal(t, err.Error(), "Failed applying the predicates: Please provide --state or --severity")
+}
+
+func TestFileGlobRegexp(t *testing.T) {
+	assert.Assert(t, fileGlobRegexp("test/**").MatchString("test/a/b.tf"))
+	assert.Assert(t, fileGlobRegexp("test/").MatchString("test/b.tf"))
+	assert.Assert(t, fileGlobRegexp("**/test/*.go").MatchString("src/test/a.go"))
+	assert.Assert(t, fileGlobRegexp("**/test/*.go").MatchString("test/a.go"))
+	assert.Assert(t, !fileGlobRegexp("test/*.go").MatchString("test/a/b.go"))
+	assert.Assert(t, !fileGlobRegexp("*.go").MatchString("src/a.go"))
+}
+
+func TestRunExportTriageCommand(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "export.csv")
+	execCmdNilAssertion(t, "triage", "export", "--project-id", "MOCK", "--file", exportFile)
+	content, err := os.ReadFile(exportFile)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		string(content),
+		"similarityId,projectId,scannerType,state,severity,comment\n"+
+			"MOCK-SAST,MOCK,sast,CONFIRMED,HIGH,MOCK\n"+
+			"MOCK-KICS,MOCK,kics,CONFIRMED,HIGH,MOCK\n",
+	)
+}
+
+func TestRunImportTriageCommand(t *testing.T) {
+	execCmdNilAssertion(t, "triage", "import", "--project-id", "MOCK", "--file", "data/triage/export.csv")
+}
+
+func TestRunImportTriageCommandWithNoInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "import")
+	assert.Equal(t, err.Error(), "required flag(s) \"file\", \"project-id\" not set")
+}
+
+func TestRunApplyRulesTriageCommand(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.jsonl")
+	execCmdNilAssertion(
+		t,
+		"triage", "apply-rules",
+		"--scan-id", "MOCK",
+		"--rules", "data/triage/rules.yaml",
+		"--audit-log", auditLog,
+	)
+	content, err := os.ReadFile(auditLog)
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, len(lines), 3)
+	assert.Assert(t, strings.Contains(lines[0], `"rule":"sast-high"`), lines[0])
+	assert.Assert(t, strings.Contains(lines[0], `"severity":"MEDIUM"`), lines[0])
+	assert.Assert(t, strings.Contains(lines[0], `"status":"Updated"`), lines[0])
+	assert.Assert(t, strings.Contains(lines[1], `"rule":"sca-mock"`), lines[1])
+	assert.Assert(t, strings.Contains(lines[1], `"status":"Skipped"`), lines[1])
+	assert.Assert(t, strings.Contains(lines[2], `"rule":"kics-tests"`), lines[2])
+	assert.Assert(t, strings.Contains(lines[2], `"state":"NOT_EXPLOITABLE"`), lines[2])
+}
+
+func TestRunApplyRulesTriageCommandDryRun(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "audit.jsonl")
+	execCmdNilAssertion(
+		t,
+		"triage", "apply-rules",
+		"--scan-id", "MOCK",
+		"--rules", "data/triage/rules.yaml",
+		"--audit-log", auditLog,
+		"--dry-run",
+	)
+	content, err := os.ReadFile(auditLog)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(content), `"dryRun":true,"status":"Dry run"`), string(content))
+}
+
+// postedPredicatesMockWrapper returns the predicates posted so far as the history of the results
+type postedPredicatesMockWrapper struct {
+	mock.ResultsPredicatesMockWrapper
+	posted []wrappers.PredicateRequest
+}
+
+func (w *postedPredicatesMockWrapper) PredicateSeverityAndState(predicate *wrappers.PredicateRequest) (
+	*wrappers.WebError, error,
+) {
+	w.posted = append(w.posted, *predicate)
+	return nil, nil
+}
+
+func (w *postedPredicatesMockWrapper) GetAllPredicatesForSimilarityID(similarityID, projectID, _ string) (
+	*wrappers.PredicatesCollectionResponseModel, *wrappers.WebError, error,
+) {
+	history := wrappers.PredicateHistory{ProjectID: projectID, SimilarityID: similarityID}
+	for i := range w.posted {
+		if w.posted[i].SimilarityID != similarityID {
+			continue
+		}
+		history.Predicates = append(
+			history.Predicates, wrappers.Predicate{
+				BasePredicate: wrappers.BasePredicate{
+					SimilarityID: similarityID,
+					ProjectID:    projectID,
+					State:        w.posted[i].State,
+					Severity:     w.posted[i].Severity,
+					Comment:      w.posted[i].Comment,
+				},
+				CreatedAt: time.Unix(int64(i), 0),
+			},
+		)
+	}
+	history.TotalCount = len(history.Predicates)
+	return &wrappers.PredicatesCollectionResponseModel{
+		PredicateHistoryPerProject: []wrappers.PredicateHistory{history},
+		TotalCount:                 history.TotalCount,
+	}, nil, nil
+}
+
+func TestRunApplyRulesTriageCommandTwice(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := "rules:\n" +
+		"  - name: sast-comment\n    match:\n      engine: sast\n    set:\n      state: TO_VERIFY\n      comment: Reviewed\n" +
+		"  - name: kics-unchanged\n    match:\n      engine: kics\n    set:\n      severity: LOW\n"
+	assert.NilError(t, os.WriteFile(rulesPath, []byte(rules), 0600))
+	predicatesWrapper := &postedPredicatesMockWrapper{}
+	applyRules := func() {
+		cmd := triageApplyRulesSubCommand(predicatesWrapper, &mock.ResultsMockWrapper{}, &mock.ScansMockWrapper{})
+		cmd.SetArgs(
+			[]string{
+				"--scan-id", "MOCK",
+				"--rules", rulesPath,
+				"--audit-log", filepath.Join(t.TempDir(), "audit.jsonl"),
+			},
+		)
+		assert.NilError(t, cmd.Execute())
+	}
+
+	applyRules()
+	assert.Equal(t, len(predicatesWrapper.posted), 1)
+	assert.Equal(t, predicatesWrapper.posted[0].SimilarityID, "MOCK-SAST")
+	assert.Equal(t, predicatesWrapper.posted[0].Comment, "Reviewed")
+
+	applyRules()
+	assert.Equal(t, len(predicatesWrapper.posted), 1)
+}
+
+func TestRunApplyRulesTriageCommandInvalidRules(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"triage", "apply-rules",
+		"--scan-id", "MOCK",
+		"--rules", "data/triage/rules-invalid.yaml",
+		"--audit-log", filepath.Join(t.TempDir(), "audit.jsonl"),
+	)
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed applying the triage rules: invalid triage rules: unknown-engine: unknown engine dast; "+
+			"unknown-engine: unknown state IGNORED",
+	)
+}
+
+func TestRunApplyRulesTriageCommandWithNoInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "triage", "apply-rules")
+	assert.Equal(t, err.Error(), "required flag(s) \"rules\", \"scan-id\" not set")
+}
@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	failedGettingProjConfig   = "Failed getting the project configuration"
+	failedSettingProjConfig   = "Failed setting the project configuration"
+	failedUnsettingProjConfig = "Failed unsetting the project configuration"
+	failedExportingProjConfig = "Failed exporting the project configuration"
+	failedImportingProjConfig = "Failed importing the project configuration"
+	secretValueType           = "Secret"
+	secretValueMask           = "********"
+	projConfigFilePermission  = 0644
+)
+
+// projectConfigFile is the YAML document of 'project config export' and 'project config import'
+type projectConfigFile struct {
+	Configuration map[string]string `yaml:"configuration"`
+}
+
+type projectConfigView struct {
+	Key           string
+	Value         string
+	ValueType     string `format:"name:Type"`
+	OriginLevel   string `format:"name:Origin level"`
+	AllowOverride bool   `format:"name:Allow override"`
+}
+
+func projectConfigSubCommand(projectsWrapper wrappers.ProjectsWrapper) *cobra.Command {
+	projectConfigCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the configuration of a project",
+		Long:  "The config command enables the ability to view and edit the scan configuration of a project.",
+	}
+
+	showConfigCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective configuration of a project",
+		Long: "The show command lists every configuration entry of the project, along with the level it comes from " +
+			"and whether it can be overridden by the project. Secret values are masked.",
+		Example: heredoc.Doc(
+			`
+			$ cx project config show --project-id <project_id>
+		`,
+		),
+		RunE: runShowProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(showConfigCmd, "Project ID.")
+	addFormatFlag(showConfigCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+
+	setConfigCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set a configuration entry of a project",
+		Example: heredoc.Doc(
+			`
+			$ cx project config set --project-id <project_id> --key scan.config.sast.incremental --value true
+		`,
+		),
+		RunE: runSetProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(setConfigCmd, "Project ID.")
+	setConfigCmd.PersistentFlags().String(commonParams.ConfigKeyFlag, "", commonParams.ConfigKeyFlagUsage)
+	setConfigCmd.PersistentFlags().String(commonParams.ConfigValueFlag, "", commonParams.ConfigValueFlagUsage)
+	markFlagAsRequired(setConfigCmd, commonParams.ProjectIDFlag)
+	markFlagAsRequired(setConfigCmd, commonParams.ConfigKeyFlag)
+	markFlagAsRequired(setConfigCmd, commonParams.ConfigValueFlag)
+
+	unsetConfigCmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Remove configuration entries set on a project",
+		Long:  "The unset command removes the project value of the keys, the project then inherits them again.",
+		Example: heredoc.Doc(
+			`
+			$ cx project config unset --project-id <project_id> --key scan.config.sast.incremental
+		`,
+		),
+		RunE: runUnsetProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(unsetConfigCmd, "Project ID.")
+	unsetConfigCmd.PersistentFlags().StringSlice(commonParams.ConfigKeyFlag, []string{}, commonParams.ConfigKeyFlagUsage)
+	markFlagAsRequired(unsetConfigCmd, commonParams.ProjectIDFlag)
+	markFlagAsRequired(unsetConfigCmd, commonParams.ConfigKeyFlag)
+
+	exportConfigCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the configuration set on a project as YAML",
+		Long: "The export command writes the entries set at project level, secrets excluded, " +
+			"to a file that can be imported into other projects.",
+		Example: heredoc.Doc(
+			`
+			$ cx project config export --project-id <project_id> --file project-config.yaml
+		`,
+		),
+		RunE: runExportProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(exportConfigCmd, "Project ID.")
+	exportConfigCmd.PersistentFlags().String(commonParams.ConfigFileFlag, "", "File receiving the configuration, the standard output by default")
+	markFlagAsRequired(exportConfigCmd, commonParams.ProjectIDFlag)
+
+	importConfigCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import a YAML configuration into a project",
+		Long:  "The import command validates every entry of the file and sets them on the project at once.",
+		Example: heredoc.Doc(
+			`
+			$ cx project config import --project-id <project_id> --file project-config.yaml
+		`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				configuration:
+				  scan.config.sast.incremental: "true"
+				  scan.config.sast.presetName: Checkmarx Default
+			`,
+			),
+		},
+		RunE: runImportProjectConfigCommand(projectsWrapper),
+	}
+	addProjectIDFlag(importConfigCmd, "Project ID.")
+	importConfigCmd.PersistentFlags().String(commonParams.ConfigFileFlag, "", commonParams.ConfigFileFlagUsage)
+	markFlagAsRequired(importConfigCmd, commonParams.ProjectIDFlag)
+	markFlagAsRequired(importConfigCmd, commonParams.ConfigFileFlag)
+
+	projectConfigCmd.AddCommand(showConfigCmd, setConfigCmd, unsetConfigCmd, exportConfigCmd, importConfigCmd)
+	return projectConfigCmd
+}
+
+func runShowProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedGettingProjConfig)
+		}
+		configuration, err := getProjectConfigurations(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingProjConfig)
+		}
+		return printByFormat(cmd, toProjectConfigViews(configuration))
+	}
+}
+
+func runSetProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		key, _ := cmd.Flags().GetString(commonParams.ConfigKeyFlag)
+		value, _ := cmd.Flags().GetString(commonParams.ConfigValueFlag)
+		err := updateProjectConfigurations(projectsWrapper, projectID, map[string]string{key: value})
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedSettingProjConfig)
+		}
+		return nil
+	}
+}
+
+func runUnsetProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		keys, _ := cmd.Flags().GetStringSlice(commonParams.ConfigKeyFlag)
+		configuration, err := getProjectConfigurations(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUnsettingProjConfig)
+		}
+		entries := toProjectConfigMap(configuration)
+		for _, key := range keys {
+			entry, found := entries[key]
+			if !found {
+				return errors.Errorf("%s: unknown configuration key %s", failedUnsettingProjConfig, key)
+			}
+			if entry.OriginLevel != projOriginLevel {
+				return errors.Errorf("%s: %s is not set at project level", failedUnsettingProjConfig, key)
+			}
+		}
+		errorModel, err := projectsWrapper.DeleteConfiguration(projectID, keys)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUnsettingProjConfig)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedUnsettingProjConfig, errorModel.Code, errorModel.Message)
+		}
+		return nil
+	}
+}
+
+func runExportProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		filePath, _ := cmd.Flags().GetString(commonParams.ConfigFileFlag)
+		configuration, err := getProjectConfigurations(projectsWrapper, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingProjConfig)
+		}
+		exported := projectConfigFile{Configuration: make(map[string]string)}
+		for _, entry := range configuration {
+			if entry.OriginLevel == projOriginLevel && entry.ValueType != secretValueType {
+				exported.Configuration[entry.Key] = entry.Value
+			}
+		}
+		content, err := yaml.Marshal(exported)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingProjConfig)
+		}
+		if filePath == "" {
+			_, err = cmd.OutOrStdout().Write(content)
+		} else {
+			err = os.WriteFile(filePath, content, projConfigFilePermission)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedExportingProjConfig)
+		}
+		return nil
+	}
+}
+
+func runImportProjectConfigCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		filePath, _ := cmd.Flags().GetString(commonParams.ConfigFileFlag)
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingProjConfig)
+		}
+		imported := projectConfigFile{}
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&imported)
+		if err != nil && err != io.EOF {
+			return errors.Wrapf(err, "%s: failed parsing %s", failedImportingProjConfig, filePath)
+		}
+		if len(imported.Configuration) == 0 {
+			return errors.Errorf("%s: %s has no configuration entries", failedImportingProjConfig, filePath)
+		}
+		err = updateProjectConfigurations(projectsWrapper, projectID, imported.Configuration)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingProjConfig)
+		}
+		return nil
+	}
+}
+
+func getProjectConfigurations(
+	projectsWrapper wrappers.ProjectsWrapper,
+	projectID string,
+) ([]wrappers.ProjectConfiguration, error) {
+	configuration, errorModel, err := projectsWrapper.GetConfiguration(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	return configuration, nil
+}
+
+// updateProjectConfigurations validates the values against the effective configuration of the project and sets
+// them at project level in a single request
+func updateProjectConfigurations(
+	projectsWrapper wrappers.ProjectsWrapper,
+	projectID string,
+	values map[string]string,
+) error {
+	configuration, err := getProjectConfigurations(projectsWrapper, projectID)
+	if err != nil {
+		return err
+	}
+	entries := toProjectConfigMap(configuration)
+	var updated []wrappers.ProjectConfiguration
+	var problems []string
+	for _, entry := range configuration {
+		value, found := values[entry.Key]
+		if !found {
+			continue
+		}
+		err = validateProjectConfigValue(entry, value)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		entry.Value = value
+		entry.OriginLevel = projOriginLevel
+		updated = append(updated, entry)
+	}
+	for key := range values {
+		if _, found := entries[key]; !found {
+			problems = append(problems, "unknown configuration key "+key)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	errorModel, err := projectsWrapper.UpdateConfiguration(projectID, updated)
+	if err != nil {
+		return err
+	}
+	if errorModel != nil {
+		return errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	return nil
+}
+
+func validateProjectConfigValue(entry wrappers.ProjectConfiguration, value string) error { //nolint:gocritic
+	if !entry.AllowOverride && entry.OriginLevel != projOriginLevel {
+		return errors.Errorf("%s is set at %s level and cannot be overridden", entry.Key, entry.OriginLevel)
+	}
+	var err error
+	switch strings.ToLower(entry.ValueType) {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "integer", "int", "number":
+		_, err = strconv.Atoi(value)
+	}
+	if err != nil {
+		return errors.Errorf("invalid value %q for %s, expected %s", value, entry.Key, entry.ValueType)
+	}
+	return nil
+}
+
+func toProjectConfigMap(configuration []wrappers.ProjectConfiguration) map[string]wrappers.ProjectConfiguration {
+	entries := make(map[string]wrappers.ProjectConfiguration, len(configuration))
+	for _, entry := range configuration {
+		entries[entry.Key] = entry
+	}
+	return entries
+}
+
+func toProjectConfigViews(configuration []wrappers.ProjectConfiguration) []projectConfigView {
+	views := make([]projectConfigView, len(configuration))
+	for i, entry := range configuration {
+		value := entry.Value
+		if entry.ValueType == secretValueType && value != "" {
+			value = secretValueMask
+		}
+		views[i] = projectConfigView{
+			Key:           entry.Key,
+			Value:         value,
+			ValueType:     entry.ValueType,
+			OriginLevel:   entry.OriginLevel,
+			AllowOverride: entry.AllowOverride,
+		}
+	}
+	return views
+}
@@ -0,0 +1,356 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedImportingProjects = "Failed importing projects"
+	gitHubSCM               = "github"
+	gitLabSCM               = "gitlab"
+	azureSCM                = "azure"
+	bitBucketSCM            = "bitbucket"
+	azureBranchPrefix       = "refs/heads/"
+	bitBucketCloneLink      = "https"
+	projImportStatusCreated = "Created"
+	projImportStatusExists  = "Exists"
+	projImportStatusPlanned = "Planned"
+	projImportStatusFailed  = "Failed"
+)
+
+var (
+	scmNames = []string{gitHubSCM, gitLabSCM, azureSCM, bitBucketSCM}
+	// scmOrigins are the project origins reported to AST for every source control manager
+	scmOrigins = map[string]string{
+		gitHubSCM:    "GitHub",
+		gitLabSCM:    "GitLab",
+		azureSCM:     "Azure",
+		bitBucketSCM: "Bitbucket",
+	}
+	scmDefaultURLs = map[string]string{
+		gitHubSCM:    "https://api.github.com",
+		gitLabSCM:    "https://gitlab.com",
+		azureSCM:     "https://dev.azure.com/",
+		bitBucketSCM: "https://api.bitbucket.org/2.0/",
+	}
+)
+
+// scmWrappers groups the source control managers the projects can be imported from
+type scmWrappers struct {
+	gitHub    wrappers.GitHubWrapper
+	gitLab    wrappers.GitLabWrapper
+	azure     wrappers.AzureWrapper
+	bitBucket wrappers.BitBucketWrapper
+}
+
+// scmRepository is a repository found in an organization, with the values of the project created for it
+type scmRepository struct {
+	Name       string
+	RepoURL    string
+	MainBranch string
+	ScmRepoID  string
+}
+
+type projectImportView struct {
+	Name       string
+	RepoURL    string `format:"name:Repository URL"`
+	MainBranch string `format:"name:Main branch"`
+	ProjectID  string `format:"name:Project ID"`
+	Status     string
+	Error      string `format:"omitempty"`
+}
+
+func projectImportSubCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	scm *scmWrappers,
+) *cobra.Command {
+	importProjCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create a project for every repository of SCM organizations",
+		Long: "The project import command creates a project for every repository of the organizations, " +
+			"with its repository URL and main branch. Repositories that already have a project are skipped.",
+		Example: heredoc.Doc(
+			`
+			$ cx project import --scm github --orgs acme --token <token>
+			$ cx project import --scm gitlab --orgs acme/backend --token <token> --tags team:backend --groups Backend
+			$ cx project import --scm bitbucket --orgs acme --username <username> --password <app password> --dry-run
+		`,
+		),
+		RunE: runImportProjectsCommand(projectsWrapper, groupsWrapper, scm),
+	}
+	importProjCmd.PersistentFlags().String(
+		commonParams.SCMFlag,
+		"",
+		fmt.Sprintf(commonParams.SCMFlagUsage, strings.Join(scmNames, ", ")),
+	)
+	importProjCmd.PersistentFlags().StringSlice(commonParams.SCMOrgsFlag, []string{}, commonParams.SCMOrgsFlagUsage)
+	importProjCmd.PersistentFlags().String(commonParams.SCMTokenFlag, "", "Token of the source control manager, used by GitHub, GitLab and Azure")
+	importProjCmd.PersistentFlags().String(commonParams.URLFlag, "", commonParams.SCMURLFlagUsage)
+	importProjCmd.PersistentFlags().String(commonParams.UsernameFlag, "", "Username for Bitbucket authentication")
+	importProjCmd.PersistentFlags().String(commonParams.PasswordFlag, "", "App password for Bitbucket authentication")
+	importProjCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags of the created projects, ex: (tagA,tagB:val,etc)")
+	importProjCmd.PersistentFlags().String(commonParams.GroupList, "", "List of groups of the created projects, ex: (PowerUsers,etc)")
+	importProjCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, "Print the projects that would be created without creating them")
+	addFormatFlag(importProjCmd, printer.FormatTable, printer.FormatList, printer.FormatJSON)
+
+	markFlagAsRequired(importProjCmd, commonParams.SCMFlag)
+	markFlagAsRequired(importProjCmd, commonParams.SCMOrgsFlag)
+
+	return importProjCmd
+}
+
+func runImportProjectsCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	scm *scmWrappers,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scmName, _ := cmd.Flags().GetString(commonParams.SCMFlag)
+		scmName = strings.ToLower(scmName)
+		orgs, _ := cmd.Flags().GetStringSlice(commonParams.SCMOrgsFlag)
+		tagListStr, _ := cmd.Flags().GetString(commonParams.TagList)
+		groupListStr, _ := cmd.Flags().GetString(commonParams.GroupList)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+
+		if _, found := scmOrigins[scmName]; !found {
+			return errors.Errorf("%s: unknown scm %s, available values: %s", failedImportingProjects, scmName, strings.Join(scmNames, ", "))
+		}
+		groups, err := createGroupsMap(groupListStr, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingProjects)
+		}
+		repositories, err := listScmRepositories(cmd, scm, scmName, orgs)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingProjects)
+		}
+
+		tags := createTagMap(tagListStr)
+		views := make([]projectImportView, len(repositories))
+		failed := 0
+		for i, repository := range repositories {
+			views[i] = importProject(projectsWrapper, repository, scmOrigins[scmName], tags, groups, dryRun)
+			if views[i].Status == projImportStatusFailed {
+				failed++
+			}
+		}
+		err = printByFormat(cmd, views)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedImportingProjects)
+		}
+		if failed > 0 {
+			return errors.Errorf("%s: %d of %d projects failed", failedImportingProjects, failed, len(repositories))
+		}
+		return nil
+	}
+}
+
+// importProject creates the project of the repository, unless a project with the same name exists
+func importProject(
+	projectsWrapper wrappers.ProjectsWrapper,
+	repository scmRepository,
+	origin string,
+	tags map[string]string,
+	groups []string,
+	dryRun bool,
+) projectImportView {
+	view := projectImportView{
+		Name:       repository.Name,
+		RepoURL:    repository.RepoURL,
+		MainBranch: repository.MainBranch,
+	}
+	projectID, err := findProjectID(projectsWrapper, repository.Name)
+	if err != nil {
+		view.Status = projImportStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	if projectID != "" {
+		view.ProjectID = projectID
+		view.Status = projImportStatusExists
+		return view
+	}
+	if dryRun {
+		view.Status = projImportStatusPlanned
+		return view
+	}
+
+	projModel := wrappers.Project{
+		Name:       repository.Name,
+		RepoURL:    repository.RepoURL,
+		MainBranch: repository.MainBranch,
+		Origin:     origin,
+		ScmRepoID:  repository.ScmRepoID,
+		Tags:       tags,
+		Groups:     groups,
+	}
+	projResponseModel, errorModel, err := projectsWrapper.Create(&projModel)
+	if err == nil && errorModel != nil {
+		err = errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	if err == nil && repository.RepoURL != "" {
+		urlConf := getProjectConfiguration(repoConfKey, "repository", git, projOriginLevel, repository.RepoURL, "String", true)
+		errorModel, err = projectsWrapper.UpdateConfiguration(projResponseModel.ID, []wrappers.ProjectConfiguration{urlConf})
+		if err == nil && errorModel != nil {
+			err = errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+		}
+	}
+	if err != nil {
+		view.Status = projImportStatusFailed
+		view.Error = err.Error()
+		return view
+	}
+	view.ProjectID = projResponseModel.ID
+	view.Status = projImportStatusCreated
+	log.Printf("Created project %s\n", repository.Name)
+	return view
+}
+
+// findProjectID returns the ID of the project with exactly this name, or an empty string if there is none. The name
+// filter matches substrings, so the exact match may be on any page.
+func findProjectID(projectsWrapper wrappers.ProjectsWrapper, projectName string) (string, error) {
+	it := wrappers.NewProjectsIterator(projectsWrapper, map[string]string{"name": projectName}, wrappers.DefaultPageSize)
+	for it.Next() {
+		if it.Project().Name == projectName {
+			return it.Project().ID, nil
+		}
+	}
+	return "", it.Err()
+}
+
+// listScmRepositories lists the repositories of the organizations in the source control manager
+func listScmRepositories(cmd *cobra.Command, scm *scmWrappers, scmName string, orgs []string) ([]scmRepository, error) {
+	token, _ := cmd.Flags().GetString(commonParams.SCMTokenFlag)
+	url, _ := cmd.Flags().GetString(commonParams.URLFlag)
+	if url == "" {
+		url = scmDefaultURLs[scmName]
+	}
+
+	var repositories []scmRepository
+	for _, org := range orgs {
+		var orgRepositories []scmRepository
+		var err error
+		switch scmName {
+		case gitHubSCM:
+			viper.Set(commonParams.URLFlag, url)
+			viper.Set(commonParams.SCMTokenFlag, token)
+			orgRepositories, err = listGitHubRepositories(scm.gitHub, org)
+		case gitLabSCM:
+			viper.Set(commonParams.GitLabURLFlag, url)
+			viper.Set(commonParams.SCMTokenFlag, token)
+			orgRepositories, err = listGitLabRepositories(scm.gitLab, org)
+		case azureSCM:
+			orgRepositories, err = listAzureRepositories(scm.azure, url, org, token)
+		case bitBucketSCM:
+			username, _ := cmd.Flags().GetString(commonParams.UsernameFlag)
+			password, _ := cmd.Flags().GetString(commonParams.PasswordFlag)
+			orgRepositories, err = listBitBucketRepositories(scm.bitBucket, url, org, username, password)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed listing the repositories of %s", org)
+		}
+		log.Printf("Found %d repositories in %s\n", len(orgRepositories), org)
+		repositories = append(repositories, orgRepositories...)
+	}
+	return repositories, nil
+}
+
+func listGitHubRepositories(gitHubWrapper wrappers.GitHubWrapper, org string) ([]scmRepository, error) {
+	organization, err := gitHubWrapper.GetOrganization(org)
+	if err != nil {
+		return nil, err
+	}
+	repositories, err := gitHubWrapper.GetRepositories(organization)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]scmRepository, len(repositories))
+	for i := range repositories {
+		result[i] = scmRepository{
+			Name:       repositories[i].FullName,
+			RepoURL:    repositories[i].CloneURL,
+			MainBranch: repositories[i].DefaultBranch,
+			ScmRepoID:  strconv.FormatInt(repositories[i].ID, 10),
+		}
+	}
+	return result, nil
+}
+
+func listGitLabRepositories(gitLabWrapper wrappers.GitLabWrapper, group string) ([]scmRepository, error) {
+	projects, err := gitLabWrapper.GetGitLabProjects(group, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]scmRepository, len(projects))
+	for i := range projects {
+		result[i] = scmRepository{
+			Name:       projects[i].PathWithNameSpace,
+			RepoURL:    projects[i].HTTPURLToRepo,
+			MainBranch: projects[i].DefaultBranch,
+			ScmRepoID:  strconv.Itoa(projects[i].ID),
+		}
+	}
+	return result, nil
+}
+
+func listAzureRepositories(azureWrapper wrappers.AzureWrapper, url, org, token string) ([]scmRepository, error) {
+	projects, err := azureWrapper.GetProjects(url, org, token)
+	if err != nil {
+		return nil, err
+	}
+	var result []scmRepository
+	for _, project := range projects.Projects {
+		repositories, err := azureWrapper.GetRepositories(url, org, project.Name, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range repositories.Repos {
+			result = append(
+				result,
+				scmRepository{
+					Name:       strings.Join([]string{org, project.Name, repository.Name}, "/"),
+					RepoURL:    repository.RemoteURL,
+					MainBranch: strings.TrimPrefix(repository.DefaultBranch, azureBranchPrefix),
+					ScmRepoID:  repository.ID,
+				},
+			)
+		}
+	}
+	return result, nil
+}
+
+func listBitBucketRepositories(
+	bitBucketWrapper wrappers.BitBucketWrapper,
+	url, workspace, username, password string,
+) ([]scmRepository, error) {
+	repositories, err := bitBucketWrapper.GetRepositories(url, workspace, username, password)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]scmRepository, len(repositories.Values))
+	for i := range repositories.Values {
+		repository := &repositories.Values[i]
+		result[i] = scmRepository{
+			Name:       repository.Name,
+			MainBranch: repository.MainBranch.Name,
+			ScmRepoID:  repository.UUID,
+		}
+		for _, link := range repository.Links.Clone {
+			if link.Name == bitBucketCloneLink {
+				result[i].RepoURL = link.Href
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedGettingOverview = "Failed getting the projects overview"
+	overviewStatusOK      = "OK"
+	overviewStatusNoScan  = "No completed scan"
+	hoursPerDay           = 24
+)
+
+type projectOverviewView struct {
+	ProjectID   string    `format:"name:Project ID"`
+	ProjectName string    `format:"name:Project name"`
+	ScanID      string    `format:"name:Scan ID"`
+	Branch      string    `format:"name:Branch"`
+	ScannedAt   time.Time `format:"name:Scanned at;time:01-02-06 15:04:05"`
+	ScanAgeDays int       `format:"name:Scan age (days)"`
+	SastHigh    int       `format:"name:SAST high"`
+	SastMedium  int       `format:"name:SAST medium"`
+	SastLow     int       `format:"name:SAST low"`
+	KicsHigh    int       `format:"name:KICS high"`
+	KicsMedium  int       `format:"name:KICS medium"`
+	KicsLow     int       `format:"name:KICS low"`
+	ScaHigh     int       `format:"name:SCA high"`
+	ScaMedium   int       `format:"name:SCA medium"`
+	ScaLow      int       `format:"name:SCA low"`
+	ScanStatus  string    `format:"name:Status"`
+}
+
+func projectOverviewSubCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+) *cobra.Command {
+	overviewCmd := &cobra.Command{
+		Use:   "overview",
+		Short: "Show the risk of every project based on its latest scan",
+		Long: "The overview command shows, for every project, the branch and age of its latest completed scan " +
+			"and the number of high, medium and low results found by each engine, not counting the ones triaged as not exploitable.",
+		Example: heredoc.Doc(
+			`
+			$ cx project overview
+			$ cx project overview --tags team:backend --groups AppSec --format csv > overview.csv
+		`,
+		),
+		RunE: runProjectOverviewCommand(projectsWrapper, groupsWrapper, scansWrapper, resultsWrapper),
+	}
+	overviewCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterProjectsListFlagUsage)
+	overviewCmd.PersistentFlags().String(commonParams.TagList, "", "Only include projects with all the tags, ex: (tagA,tagB:val,etc)")
+	overviewCmd.PersistentFlags().String(commonParams.GroupList, "", "Only include projects in one of the groups, ex: (PowerUsers,etc)")
+	addConcurrencyFlags(overviewCmd)
+	addFormatFlag(overviewCmd, printer.FormatTable, printer.FormatJSON, printer.FormatCSV, printer.FormatList)
+
+	return overviewCmd
+}
+
+func runProjectOverviewCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		tagListStr, _ := cmd.Flags().GetString(commonParams.TagList)
+		groupListStr, _ := cmd.Flags().GetString(commonParams.GroupList)
+		concurrency, rateLimit, err := getConcurrencyFlags(cmd)
+		if err != nil {
+			return err
+		}
+		params, err := getFilters(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingOverview)
+		}
+		groups, err := createGroupsMap(groupListStr, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingOverview)
+		}
+
+		var projects []wrappers.ProjectResponseModel
+		tags := createTagMap(tagListStr)
+		it := wrappers.NewProjectsIterator(projectsWrapper, params, wrappers.DefaultPageSize)
+		for it.Next() {
+			if project := it.Project(); hasProjectTags(project, tags) && isProjectInGroups(project, groups) {
+				projects = append(projects, *project)
+			}
+		}
+		if it.Err() != nil {
+			return errors.Wrapf(it.Err(), "%s", failedGettingOverview)
+		}
+
+		views := make([]projectOverviewView, len(projects))
+		runConcurrently(
+			len(projects), concurrency, rateLimit, func(i int) {
+				views[i] = getProjectOverview(scansWrapper, resultsWrapper, &projects[i])
+			},
+		)
+		return printByFormat(cmd, views)
+	}
+}
+
+// hasProjectTags reports whether the project has every tag. Tags without a value match any value.
+func hasProjectTags(project *wrappers.ProjectResponseModel, tags map[string]string) bool {
+	for key, value := range tags {
+		projectValue, found := project.Tags[key]
+		if !found || (value != "" && projectValue != value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isProjectInGroups reports whether the project belongs to one of the groups, or true when there are no groups
+func isProjectInGroups(project *wrappers.ProjectResponseModel, groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, group := range groups {
+		for _, projectGroup := range project.Groups {
+			if group == projectGroup {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getProjectOverview summarizes the results of the latest completed scan of the project. Errors are reported in
+// the status of the row so that a single project does not hide the others.
+func getProjectOverview(
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	project *wrappers.ProjectResponseModel,
+) projectOverviewView {
+	view := projectOverviewView{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		ScanStatus:  overviewStatusOK,
+	}
+	scan, err := getLatestScan(scansWrapper, project.ID)
+	if err != nil {
+		view.ScanStatus = err.Error()
+		return view
+	}
+	if scan == nil {
+		view.ScanStatus = overviewStatusNoScan
+		return view
+	}
+	view.ScanID = scan.ID
+	view.Branch = scan.Branch
+	view.ScannedAt = scan.CreatedAt
+	view.ScanAgeDays = int(time.Since(scan.CreatedAt).Hours() / hoursPerDay)
+
+	results, err := ReadResults(resultsWrapper, scan.ID, make(map[string]string))
+	if err != nil {
+		view.ScanStatus = err.Error()
+		return view
+	}
+	if results == nil {
+		return view
+	}
+	for _, result := range results.Results {
+		countOverviewResult(&view, result)
+	}
+	return view
+}
+
+// countOverviewResult counts the result by engine and severity, unless it was triaged as not exploitable
+func countOverviewResult(view *projectOverviewView, result *wrappers.ScanResult) {
+	if strings.EqualFold(result.State, notExploitable) {
+		return
+	}
+	var high, medium, low *int
+	switch strings.ToLower(result.Type) {
+	case commonParams.SastType:
+		high, medium, low = &view.SastHigh, &view.SastMedium, &view.SastLow
+	case commonParams.KicsType:
+		high, medium, low = &view.KicsHigh, &view.KicsMedium, &view.KicsLow
+	case commonParams.ScaType:
+		high, medium, low = &view.ScaHigh, &view.ScaMedium, &view.ScaLow
+	default:
+		return
+	}
+	switch strings.ToLower(result.Severity) {
+	case highLabel:
+		*high++
+	case mediumLabel:
+		*medium++
+	case lowLabel:
+		*low++
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 const (
 	failedCreatingProj    = "Failed creating a project"
+	failedUpdatingProj    = "Failed updating a project"
 	failedGettingProj     = "Failed getting a project"
 	failedDeletingProj    = "Failed deleting a project"
 	failedGettingBranches = "Failed getting branches for project"
@@ -59,7 +60,16 @@ var (
 	)
 )
 
-func NewProjectCommand(projectsWrapper wrappers.ProjectsWrapper, groupsWrapper wrappers.GroupsWrapper) *cobra.Command {
+func NewProjectCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	gitHubWrapper wrappers.GitHubWrapper,
+	azureWrapper wrappers.AzureWrapper,
+	bitBucketWrapper wrappers.BitBucketWrapper,
+	gitLabWrapper wrappers.GitLabWrapper,
+) *cobra.Command {
 	projCmd := &cobra.Command{
 		Use:   "project",
 		Short: "Manage projects",
@@ -98,12 +108,34 @@ func NewProjectCommand(projectsWrapper wrappers.ProjectsWrapper, groupsWrapper w
 	createProjCmd.PersistentFlags().String(commonParams.SSHKeyFlag, "", "Path to ssh private key")
 	createProjCmd.PersistentFlags().String(commonParams.RepoURLFlag, "", "Repository URL")
 
+	updateProjCmd := &cobra.Command{
+		Use:   "update",
+		Short: "Updates an existing project",
+		Long: "The project update command changes the name, main branch, repository, tags or groups of a project. " +
+			"Only the provided flags are changed.",
+		Example: heredoc.Doc(
+			`
+			$ cx project update --project-id <project_id> --project-name <New Name>
+			$ cx project update --project-id <project_id> --branch main --tags tagA,tagB:val --groups PowerUsers
+		`,
+		),
+		RunE: runUpdateProjectCommand(projectsWrapper, groupsWrapper),
+	}
+	addProjectIDFlag(updateProjCmd, "Project ID to update.")
+	updateProjCmd.PersistentFlags().String(commonParams.TagList, "", "List of tags replacing the current ones, ex: (tagA,tagB:val,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.GroupList, "", "List of groups replacing the current ones, ex: (PowerUsers,etc)")
+	updateProjCmd.PersistentFlags().String(commonParams.ProjectName, "", "New name of the project")
+	updateProjCmd.PersistentFlags().String(commonParams.MainBranchFlag, "", "Main branch")
+	updateProjCmd.PersistentFlags().String(commonParams.SSHKeyFlag, "", "Path to ssh private key")
+	updateProjCmd.PersistentFlags().String(commonParams.RepoURLFlag, "", "Repository URL")
+
 	listProjectsCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all projects in the system",
 		Example: heredoc.Doc(
 			`
 			$ cx project list --format list
+			$ cx project list --all --format json
 		`,
 		),
 		Annotations: map[string]string{
@@ -116,6 +148,7 @@ func NewProjectCommand(projectsWrapper wrappers.ProjectsWrapper, groupsWrapper w
 		RunE: runListProjectsCommand(projectsWrapper),
 	}
 	listProjectsCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterProjectsListFlagUsage)
+	addAllPagesFlags(listProjectsCmd)
 
 	showProjectCmd := &cobra.Command{
 		Use:   "show",
@@ -193,13 +226,37 @@ func NewProjectCommand(projectsWrapper wrappers.ProjectsWrapper, groupsWrapper w
 		RunE: runGetProjectsTagsCommand(projectsWrapper),
 	}
 
+	projectConfigCmd := projectConfigSubCommand(projectsWrapper)
+	overviewCmd := projectOverviewSubCommand(projectsWrapper, groupsWrapper, scansWrapper, resultsWrapper)
+	importProjCmd := projectImportSubCommand(
+		projectsWrapper,
+		groupsWrapper,
+		&scmWrappers{
+			gitHub:    gitHubWrapper,
+			gitLab:    gitLabWrapper,
+			azure:     azureWrapper,
+			bitBucket: bitBucketWrapper,
+		},
+	)
+
 	addFormatFlagToMultipleCommands(
-		[]*cobra.Command{showProjectCmd, listProjectsCmd, createProjCmd},
+		[]*cobra.Command{showProjectCmd, listProjectsCmd, createProjCmd, updateProjCmd},
 		printer.FormatTable,
 		printer.FormatJSON,
 		printer.FormatList,
 	)
-	projCmd.AddCommand(createProjCmd, projectBranchesCmd, showProjectCmd, listProjectsCmd, deleteProjCmd, tagsCmd)
+	projCmd.AddCommand(
+		createProjCmd,
+		updateProjCmd,
+		projectBranchesCmd,
+		showProjectCmd,
+		listProjectsCmd,
+		deleteProjCmd,
+		tagsCmd,
+		projectConfigCmd,
+		importProjCmd,
+		overviewCmd,
+	)
 	return projCmd
 }
 
@@ -332,30 +389,138 @@ func runCreateProjectCommand(
 	}
 }
 
-func updateProjectConfigurationIfNeeded(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper, projectID string) error {
-	// Just update project configuration id a repository url is defined
-	if cmd.Flags().Changed(commonParams.RepoURLFlag) {
-		var projectConfigurations []wrappers.ProjectConfiguration
-
-		repoURL, _ := cmd.Flags().GetString(commonParams.RepoURLFlag)
+func runUpdateProjectCommand(
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		if projectID == "" {
+			return errors.Errorf("%s: Please provide a project ID", failedUpdatingProj)
+		}
+		if !isAnyFlagChanged(
+			cmd,
+			commonParams.ProjectName,
+			commonParams.MainBranchFlag,
+			commonParams.RepoURLFlag,
+			commonParams.SSHKeyFlag,
+			commonParams.TagList,
+			commonParams.GroupList,
+		) {
+			return errors.Errorf("%s: Please provide at least one field to update", failedUpdatingProj)
+		}
+		err := validateConfiguration(cmd)
+		if err != nil {
+			return err
+		}
 
-		urlConf := getProjectConfiguration(repoConfKey, "repository", git, projOriginLevel, repoURL, "String", true)
+		project, errorModel, err := projectsWrapper.GetByID(projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProj)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedUpdatingProj, errorModel.Code, errorModel.Message)
+		}
+		projModel, err := toUpdatedProject(cmd, project, groupsWrapper)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProj)
+		}
+		payload, _ := json.Marshal(projModel)
+		logger.PrintIfVerbose(fmt.Sprintf("Payload to projects service: %s\n", string(payload)))
+		errorModel, err = projectsWrapper.Update(projectID, projModel)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProj)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedUpdatingProj, errorModel.Code, errorModel.Message)
+		}
 
-		projectConfigurations = append(projectConfigurations, urlConf)
+		if cmd.Flags().Changed(commonParams.RepoURLFlag) {
+			projectConfigurations, _, configErr := getRepositoryConfigurations(cmd)
+			if configErr != nil {
+				return errors.Wrapf(configErr, "%s", failedUpdatingProj)
+			}
+			errorModel, err = projectsWrapper.UpdateConfiguration(projectID, projectConfigurations)
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedUpdatingProj)
+			}
+			if errorModel != nil {
+				return errors.Errorf(ErrorCodeFormat, failedUpdatingProj, errorModel.Code, errorModel.Message)
+			}
+		}
 
-		if cmd.Flags().Changed(commonParams.SSHKeyFlag) {
-			sshKeyPath, _ := cmd.Flags().GetString(commonParams.SSHKeyFlag)
+		project, errorModel, err = projectsWrapper.GetByID(projectID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedUpdatingProj)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedUpdatingProj, errorModel.Code, errorModel.Message)
+		}
+		return printByFormat(cmd, toProjectView(*project))
+	}
+}
 
-			sshKey, sshErr := util.ReadFileAsString(sshKeyPath)
-			if sshErr != nil {
-				return sshErr
-			}
+// toUpdatedProject applies the changed flags to the current values of the project
+func toUpdatedProject(
+	cmd *cobra.Command,
+	project *wrappers.ProjectResponseModel,
+	groupsWrapper wrappers.GroupsWrapper,
+) (*wrappers.Project, error) {
+	projModel := &wrappers.Project{
+		Name:       project.Name,
+		RepoURL:    project.RepoURL,
+		MainBranch: project.MainBranch,
+		Origin:     project.Origin,
+		ScmRepoID:  project.ScmRepoID,
+		Tags:       project.Tags,
+		Groups:     project.Groups,
+	}
+	if cmd.Flags().Changed(commonParams.ProjectName) {
+		projModel.Name, _ = cmd.Flags().GetString(commonParams.ProjectName)
+		if strings.TrimSpace(projModel.Name) == "" {
+			return nil, errors.New("flag needs an argument: --project-name")
+		}
+	}
+	if cmd.Flags().Changed(commonParams.MainBranchFlag) {
+		projModel.MainBranch, _ = cmd.Flags().GetString(commonParams.MainBranchFlag)
+	}
+	if cmd.Flags().Changed(commonParams.RepoURLFlag) {
+		projModel.RepoURL, _ = cmd.Flags().GetString(commonParams.RepoURLFlag)
+	}
+	if cmd.Flags().Changed(commonParams.TagList) {
+		tagListStr, _ := cmd.Flags().GetString(commonParams.TagList)
+		projModel.Tags = createTagMap(tagListStr)
+	}
+	if cmd.Flags().Changed(commonParams.GroupList) {
+		groupListStr, _ := cmd.Flags().GetString(commonParams.GroupList)
+		groups, err := createGroupsMap(groupListStr, groupsWrapper)
+		if err != nil {
+			return nil, err
+		}
+		projModel.Groups = groups
+	}
+	return projModel, nil
+}
 
-			viper.Set(commonParams.SSHValue, sshKey)
+func isAnyFlagChanged(cmd *cobra.Command, flags ...string) bool {
+	for _, flag := range flags {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
 
-			sshKeyConf := getProjectConfiguration(sshConfKey, "sshKey", git, projOriginLevel, sshKey, "Secret", true)
+func updateProjectConfigurationIfNeeded(cmd *cobra.Command, projectsWrapper wrappers.ProjectsWrapper, projectID string) error {
+	// Just update project configuration id a repository url is defined
+	if cmd.Flags().Changed(commonParams.RepoURLFlag) {
+		projectConfigurations, sshKey, err := getRepositoryConfigurations(cmd)
+		if err != nil {
+			return err
+		}
 
-			projectConfigurations = append(projectConfigurations, sshKeyConf)
+		if sshKey != "" {
+			viper.Set(commonParams.SSHValue, sshKey)
 		}
 
 		_, configErr := projectsWrapper.UpdateConfiguration(projectID, projectConfigurations)
@@ -367,6 +532,35 @@ func updateProjectConfigurationIfNeeded(cmd *cobra.Command, projectsWrapper wrap
 	return nil
 }
 
+// getRepositoryConfigurations returns the project configurations of the repository url and ssh key flags,
+// along with the ssh key read from the file
+func getRepositoryConfigurations(cmd *cobra.Command) ([]wrappers.ProjectConfiguration, string, error) {
+	var projectConfigurations []wrappers.ProjectConfiguration
+
+	repoURL, _ := cmd.Flags().GetString(commonParams.RepoURLFlag)
+
+	urlConf := getProjectConfiguration(repoConfKey, "repository", git, projOriginLevel, repoURL, "String", true)
+
+	projectConfigurations = append(projectConfigurations, urlConf)
+
+	var sshKey string
+	if cmd.Flags().Changed(commonParams.SSHKeyFlag) {
+		sshKeyPath, _ := cmd.Flags().GetString(commonParams.SSHKeyFlag)
+
+		var sshErr error
+		sshKey, sshErr = util.ReadFileAsString(sshKeyPath)
+		if sshErr != nil {
+			return nil, "", sshErr
+		}
+
+		sshKeyConf := getProjectConfiguration(sshConfKey, "sshKey", git, projOriginLevel, sshKey, "Secret", true)
+
+		projectConfigurations = append(projectConfigurations, sshKeyConf)
+	}
+
+	return projectConfigurations, sshKey, nil
+}
+
 func getProjectConfiguration(key, name, category, level, value, valueType string, allowOverride bool) wrappers.ProjectConfiguration {
 	config := wrappers.ProjectConfiguration{}
 	config.Key = key
@@ -433,8 +627,16 @@ func runListProjectsCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedGettingAll)
 		}
+		all, pageSize, concurrency, err := getAllPagesFlags(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingAll)
+		}
+		listWrapper := projectsWrapper
+		if all {
+			listWrapper = wrappers.NewAllPagesProjectsWrapper(projectsWrapper, pageSize, concurrency)
+		}
 
-		allProjectsModel, errorModel, err = projectsWrapper.Get(params)
+		allProjectsModel, errorModel, err = listWrapper.Get(params)
 		if err != nil {
 			return errors.Wrapf(err, "%s\n", failedGettingAll)
 		}
@@ -443,6 +645,7 @@ func runListProjectsCommand(projectsWrapper wrappers.ProjectsWrapper) func(cmd *
 		if errorModel != nil {
 			return errors.Errorf(ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
 		} else if allProjectsModel != nil && allProjectsModel.Projects != nil {
+			warnTruncated("projects", params, len(allProjectsModel.Projects), allProjectsModel.FilteredTotalCount)
 			err = printByFormat(cmd, toProjectViews(allProjectsModel.Projects))
 			if err != nil {
 				return err
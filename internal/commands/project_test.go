@@ -3,11 +3,19 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"gotest.tools/assert"
 
 	"github.com/checkmarx/ast-cli/internal/commands/util"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 )
 
 func TestProjectHelp(t *testing.T) {
@@ -69,6 +77,15 @@ func TestRunGetAllProjectsCommand(t *testing.T) {
 	execCmdNilAssertion(t, "project", "list")
 }
 
+func TestRunGetAllProjectsCommandAllPages(t *testing.T) {
+	execCmdNilAssertion(t, "project", "list", "--all", "--filter", "limit=20")
+}
+
+func TestRunGetAllProjectsCommandAllPagesInvalidLimit(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "list", "--all", "--filter", "limit=none")
+	assert.Equal(t, err.Error(), "Failed listing: Invalid limit filter none")
+}
+
 func TestRunGetAllProjectsCommandFlagNonExist(t *testing.T) {
 	err := execCmdNotNilAssertion(t, "project", "list", "--chibutero")
 	assert.Assert(t, err.Error() == unknownFlag)
@@ -164,3 +181,307 @@ func TestCreateProjectWithSSHKey(t *testing.T) {
 
 	execCmdNilAssertion(t, append(baseArgs, "--ssh-key", "data/sources.zip", "--repo-url", "git@github.com:dummyRepo/dummyProject.git")...)
 }
+
+func TestRunUpdateProjectCommand(t *testing.T) {
+	execCmdNilAssertion(
+		t,
+		"project", "update",
+		"--project-id", "MOCK",
+		"--project-name", "renamed_project",
+		"--branch", "main",
+		"--tags", "tagA,tagB:val",
+	)
+}
+
+func TestRunUpdateProjectCommandWithRepository(t *testing.T) {
+	execCmdNilAssertion(t, "project", "update", "--project-id", "MOCK", "--repo-url", "https://github.com/dummyuser/dummy_project.git")
+}
+
+func TestRunUpdateProjectCommandWithUnknownGroup(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--groups", "group")
+	assert.Equal(t, err.Error(), "Failed updating a project: Failed finding groups: [group]")
+}
+
+func TestRunUpdateProjectCommandWithNoProjectID(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-name", "renamed_project")
+	assert.Equal(t, err.Error(), "Failed updating a project: Please provide a project ID")
+}
+
+func TestRunUpdateProjectCommandWithNoChanges(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK")
+	assert.Equal(t, err.Error(), "Failed updating a project: Please provide at least one field to update")
+}
+
+func TestRunUpdateProjectCommandWithEmptyName(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--project-name", " ")
+	assert.Equal(t, err.Error(), "Failed updating a project: flag needs an argument: --project-name")
+}
+
+func TestRunUpdateProjectCommandWithSSHKeyAndNoRepo(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "update", "--project-id", "MOCK", "--ssh-key", "dummy_key")
+	assert.Equal(t, err.Error(), mandatoryRepoURLError)
+}
+
+func TestRunShowProjectConfigCommand(t *testing.T) {
+	execCmdNilAssertion(t, "project", "config", "show", "--project-id", "MOCK", "--format", "json")
+}
+
+func TestRunShowProjectConfigCommandNoProjectID(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "config", "show")
+	assert.Equal(t, err.Error(), "Failed getting the project configuration: Please provide a project ID")
+}
+
+func TestToProjectConfigViewsMasksSecrets(t *testing.T) {
+	views := toProjectConfigViews(
+		[]wrappers.ProjectConfiguration{
+			{Key: sshConfKey, Value: "key", ValueType: secretValueType},
+			{Key: repoConfKey, Value: "url", ValueType: "String"},
+		},
+	)
+	assert.Equal(t, views[0].Value, secretValueMask)
+	assert.Equal(t, views[1].Value, "url")
+}
+
+func TestRunSetProjectConfigCommand(t *testing.T) {
+	execCmdNilAssertion(
+		t,
+		"project", "config", "set",
+		"--project-id", "MOCK",
+		"--key", "scan.config.sast.incremental",
+		"--value", "true",
+	)
+}
+
+func TestRunSetProjectConfigCommandInvalidValue(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"project", "config", "set",
+		"--project-id", "MOCK",
+		"--key", "scan.config.sast.incremental",
+		"--value", "yes please",
+	)
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed setting the project configuration: invalid value \"yes please\" for scan.config.sast.incremental, expected Bool",
+	)
+}
+
+func TestRunSetProjectConfigCommandLockedKey(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"project", "config", "set",
+		"--project-id", "MOCK",
+		"--key", "scan.config.sast.engineVerbose",
+		"--value", "true",
+	)
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed setting the project configuration: scan.config.sast.engineVerbose is set at Tenant level and cannot be overridden",
+	)
+}
+
+func TestRunSetProjectConfigCommandUnknownKey(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"project", "config", "set",
+		"--project-id", "MOCK",
+		"--key", "scan.config.unknown",
+		"--value", "true",
+	)
+	assert.Equal(t, err.Error(), "Failed setting the project configuration: unknown configuration key scan.config.unknown")
+}
+
+func TestRunUnsetProjectConfigCommand(t *testing.T) {
+	execCmdNilAssertion(t, "project", "config", "unset", "--project-id", "MOCK", "--key", "scan.handler.git.repository")
+}
+
+func TestRunUnsetProjectConfigCommandInheritedKey(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "config", "unset", "--project-id", "MOCK", "--key", "scan.config.sast.incremental")
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed unsetting the project configuration: scan.config.sast.incremental is not set at project level",
+	)
+}
+
+func TestRunExportProjectConfigCommand(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "config.yaml")
+	execCmdNilAssertion(t, "project", "config", "export", "--project-id", "MOCK", "--file", exportFile)
+	content, err := os.ReadFile(exportFile)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		string(content),
+		"configuration:\n    scan.handler.git.repository: https://github.com/dummyuser/dummy_project.git\n",
+	)
+}
+
+func TestRunImportProjectConfigCommand(t *testing.T) {
+	execCmdNilAssertion(t, "project", "config", "import", "--project-id", "MOCK", "--file", "data/project/config.yaml")
+}
+
+func TestRunImportProjectConfigCommandInvalidFile(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"project", "config", "import",
+		"--project-id", "MOCK",
+		"--file", "data/project/config-invalid.yaml",
+	)
+	assert.Equal(
+		t,
+		err.Error(),
+		"Failed importing the project configuration: invalid value \"sometimes\" for scan.config.sast.incremental, expected Bool; "+
+			"unknown configuration key scan.config.sast.unknown",
+	)
+}
+
+func TestRunImportProjectConfigCommandUnknownKey(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"project", "config", "import",
+		"--project-id", "MOCK",
+		"--file", "data/project/config-unknown-key.yaml",
+	)
+	assert.ErrorContains(t, err, "Failed importing the project configuration: failed parsing data/project/config-unknown-key.yaml")
+	assert.ErrorContains(t, err, "field configurations not found")
+}
+
+func TestRunImportProjectsCommandGitHub(t *testing.T) {
+	execCmdNilAssertion(t, "project", "import", "--scm", "github", "--orgs", "MOCK", "--tags", "team:a", "--format", "json")
+}
+
+func TestRunImportProjectsCommandGitLab(t *testing.T) {
+	execCmdNilAssertion(t, "project", "import", "--scm", "gitlab", "--orgs", "MOCK", "--dry-run")
+}
+
+func TestRunImportProjectsCommandAzure(t *testing.T) {
+	execCmdNilAssertion(t, "project", "import", "--scm", "azure", "--orgs", "MOCK", "--token", "MOCK")
+}
+
+func TestRunImportProjectsCommandBitBucket(t *testing.T) {
+	execCmdNilAssertion(t, "project", "import", "--scm", "bitbucket", "--orgs", "MOCK", "--username", "MOCK", "--password", "MOCK")
+}
+
+func TestRunImportProjectsCommandUnknownSCM(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "import", "--scm", "svn", "--orgs", "MOCK")
+	assert.Equal(t, err.Error(), "Failed importing projects: unknown scm svn, available values: github, gitlab, azure, bitbucket")
+}
+
+func TestRunImportProjectsCommandWithNoInput(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "import")
+	assert.Equal(t, err.Error(), "required flag(s) \"orgs\", \"scm\" not set")
+}
+
+func TestImportProject(t *testing.T) {
+	projectsWrapper := &mock.ProjectsMockWrapper{}
+	repository := scmRepository{Name: "MOCK/new-repo", RepoURL: "https://github.com/MOCK/new-repo.git", MainBranch: "main"}
+
+	view := importProject(projectsWrapper, repository, "GitHub", nil, nil, true)
+	assert.Equal(t, view.Status, projImportStatusPlanned)
+
+	view = importProject(projectsWrapper, repository, "GitHub", nil, nil, false)
+	assert.Equal(t, view.Status, projImportStatusCreated)
+
+	repository.Name = "MOCK"
+	view = importProject(projectsWrapper, repository, "GitHub", nil, nil, false)
+	assert.Equal(t, view.Status, projImportStatusExists)
+	assert.Equal(t, view.ProjectID, "MOCK")
+}
+
+func TestRunProjectOverviewCommand(t *testing.T) {
+	execCmdNilAssertion(t, "project", "overview", "--format", "csv")
+}
+
+func TestRunProjectOverviewCommandWithUnknownGroup(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "project", "overview", "--groups", "group")
+	assert.Equal(t, err.Error(), "Failed getting the projects overview: Failed finding groups: [group]")
+}
+
+func TestGetProjectOverview(t *testing.T) {
+	project := &wrappers.ProjectResponseModel{ID: "MOCK", Name: "MOCK"}
+	view := getProjectOverview(&mock.ScansMockWrapper{}, &mock.ResultsMockWrapper{}, project)
+	assert.Equal(t, view.ScanID, "MOCK")
+	assert.Equal(t, view.ScanStatus, overviewStatusOK)
+	assert.Equal(t, view.SastHigh, 1)
+	assert.Equal(t, view.ScaMedium, 1)
+	assert.Equal(t, view.KicsLow, 1)
+	assert.Equal(t, view.SastMedium+view.SastLow+view.KicsHigh+view.KicsMedium+view.ScaHigh+view.ScaLow, 0)
+}
+
+type pagedProjectsMockWrapper struct {
+	mock.ProjectsMockWrapper
+	projects []wrappers.ProjectResponseModel
+}
+
+func (m *pagedProjectsMockWrapper) Get(params map[string]string) (*wrappers.ProjectsCollectionResponseModel, *wrappers.ErrorModel, error) {
+	offset, _ := strconv.Atoi(params["offset"])
+	limit, _ := strconv.Atoi(params["limit"])
+	end := offset + limit
+	if end > len(m.projects) {
+		end = len(m.projects)
+	}
+	if offset > end {
+		offset = end
+	}
+	return &wrappers.ProjectsCollectionResponseModel{
+		FilteredTotalCount: uint(len(m.projects)),
+		Projects:           m.projects[offset:end],
+	}, nil, nil
+}
+
+func TestRunProjectOverviewCommandReadsEveryPage(t *testing.T) {
+	paged := &pagedProjectsMockWrapper{}
+	for i := 0; i < 2*wrappers.DefaultPageSize+10; i++ {
+		project := wrappers.ProjectResponseModel{ID: fmt.Sprint(i), Tags: map[string]string{}}
+		if i%2 == 0 {
+			project.Tags["team"] = "a"
+		}
+		paged.projects = append(paged.projects, project)
+	}
+	cmd := projectOverviewSubCommand(paged, &mock.GroupsMockWrapper{}, &mock.ScansMockWrapper{}, &mock.ResultsMockWrapper{})
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetArgs([]string{"--format", "json", "--tags", "team:a", "--rate-limit", "0"})
+	assert.NilError(t, cmd.Execute())
+
+	var views []projectOverviewView
+	assert.NilError(t, json.Unmarshal(output.Bytes(), &views))
+	assert.Equal(t, len(views), wrappers.DefaultPageSize+5)
+	assert.Equal(t, views[len(views)-1].ProjectID, fmt.Sprint(2*wrappers.DefaultPageSize+8))
+}
+
+func TestCountOverviewResultSkipsNotExploitable(t *testing.T) {
+	view := projectOverviewView{}
+	for _, state := range []string{"TO_VERIFY", "NOT_EXPLOITABLE", "not_exploitable", "CONFIRMED"} {
+		countOverviewResult(&view, &wrappers.ScanResult{Type: "sast", Severity: "HIGH", State: state})
+	}
+	assert.Equal(t, view.SastHigh, 2)
+}
+
+func TestFindProjectIDOnALaterPage(t *testing.T) {
+	paged := &pagedProjectsMockWrapper{}
+	for i := 0; i < wrappers.DefaultPageSize+10; i++ {
+		paged.projects = append(paged.projects, wrappers.ProjectResponseModel{ID: fmt.Sprint(i), Name: fmt.Sprintf("svc-%d", i)})
+	}
+	paged.projects = append(paged.projects, wrappers.ProjectResponseModel{ID: "exact", Name: "svc"})
+
+	projectID, err := findProjectID(paged, "svc")
+	assert.NilError(t, err)
+	assert.Equal(t, projectID, "exact")
+
+	projectID, err = findProjectID(paged, "other")
+	assert.NilError(t, err)
+	assert.Equal(t, projectID, "")
+}
+
+func TestProjectOverviewFilters(t *testing.T) {
+	project := &wrappers.ProjectResponseModel{Tags: map[string]string{"team": "a", "env": ""}, Groups: []string{"g1"}}
+	assert.Assert(t, hasProjectTags(project, createTagMap("team:a,env")))
+	assert.Assert(t, hasProjectTags(project, createTagMap("team")))
+	assert.Assert(t, !hasProjectTags(project, createTagMap("team:b")))
+	assert.Assert(t, isProjectInGroups(project, nil))
+	assert.Assert(t, isProjectInGroups(project, []string{"g2", "g1"}))
+	assert.Assert(t, !isProjectInGroups(project, []string{"g2"}))
+}
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	groupByBfl          = "bfl"
+	failedGroupingByBfl = "Failed grouping the results by best fix location"
+)
+
+type bestFixLocationView struct {
+	FileName        string `format:"name:File"`
+	Line            uint
+	Column          uint
+	Name            string `format:"name:Element"`
+	Vulnerabilities string
+	Results         int
+}
+
+// newBflGroupEnricher groups the SAST results by their best fix location. The best fix locations of every query of
+// the scan are requested once, and the locations shared by several queries are merged, most results first.
+func newBflGroupEnricher(bflWrapper wrappers.BflWrapper) resultsEnricher {
+	return func(results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error {
+		if results == nil {
+			return nil
+		}
+		var queryIDs []string
+		queryNames := make(map[string]string)
+		byHash := make(map[string]*wrappers.ScanResult)
+		for _, result := range results.Results {
+			if result.Type != commonParams.SastType || result.ScanResultData.QueryID == nil {
+				continue
+			}
+			queryID := wrappers.FormatQueryID(result.ScanResultData.QueryID)
+			if _, found := queryNames[queryID]; !found {
+				queryNames[queryID] = strings.ReplaceAll(result.ScanResultData.QueryName, "_", " ")
+				queryIDs = append(queryIDs, queryID)
+			}
+			if result.ScanResultData.ResultHash != "" {
+				byHash[result.ScanResultData.ResultHash] = result
+			}
+		}
+
+		trees, err := getQueriesBflTrees(bflWrapper, summary.ScanID, queryIDs)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGroupingByBfl)
+		}
+
+		groups := make(map[string]*wrappers.BestFixLocationSummary)
+		for _, queryID := range queryIDs {
+			for _, tree := range trees[queryID] {
+				if tree.BFL == nil {
+					continue
+				}
+				key := fmt.Sprintf("%s:%d:%d", tree.BFL.FileName, tree.BFL.Line, tree.BFL.Column)
+				group, found := groups[key]
+				if !found {
+					group = &wrappers.BestFixLocationSummary{
+						FileName: tree.BFL.FileName,
+						Line:     tree.BFL.Line,
+						Column:   tree.BFL.Column,
+						Name:     tree.BFL.Name,
+					}
+					groups[key] = group
+					summary.BestFixLocations = append(summary.BestFixLocations, group)
+				}
+				if name := queryNames[queryID]; name != "" && !utils.ContainsFold(group.Queries, name) {
+					group.Queries = append(group.Queries, name)
+				}
+				for _, data := range tree.Results {
+					if data == nil {
+						continue
+					}
+					group.Results++
+					if result := byHash[data.ResultHash]; result != nil && data.ResultHash != "" {
+						result.BestFixLocation = tree.BFL
+					}
+				}
+			}
+		}
+		sort.SliceStable(
+			summary.BestFixLocations, func(i, j int) bool {
+				return summary.BestFixLocations[i].Results > summary.BestFixLocations[j].Results
+			},
+		)
+		return nil
+	}
+}
+
+// getQueriesBflTrees returns the best fix location trees of every query, requested once per query
+func getQueriesBflTrees(bflWrapper wrappers.BflWrapper, scanID string, queryIDs []string) (map[string][]wrappers.BFLTreeModel, error) {
+	trees := make([][]wrappers.BFLTreeModel, len(queryIDs))
+	errs := make([]error, len(queryIDs))
+	runConcurrently(
+		len(queryIDs), commonParams.ConcurrencyDefault, 0, func(i int) {
+			trees[i], errs[i] = getBflTrees(bflWrapper, scanID, queryIDs[i])
+		},
+	)
+	byQuery := make(map[string][]wrappers.BFLTreeModel, len(queryIDs))
+	for i, queryID := range queryIDs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		byQuery[queryID] = trees[i]
+	}
+	return byQuery, nil
+}
+
+func getBflTrees(bflWrapper wrappers.BflWrapper, scanID, queryID string) ([]wrappers.BFLTreeModel, error) {
+	bflResponseModel, errorModel, err := bflWrapper.GetBflByScanIDAndQueryID(
+		map[string]string{
+			commonParams.ScanIDQueryParam:  scanID,
+			commonParams.QueryIDQueryParam: queryID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("%s: CODE: %d, %s", failedGettingBfl, errorModel.Code, errorModel.Message)
+	}
+	if bflResponseModel == nil {
+		return nil, nil
+	}
+	return bflResponseModel.Trees, nil
+}
+
+func writeConsoleBestFixLocations(summary *wrappers.ResultSummary) error {
+	if len(summary.BestFixLocations) == 0 {
+		return nil
+	}
+	views := make([]bestFixLocationView, len(summary.BestFixLocations))
+	for i, location := range summary.BestFixLocations {
+		views[i] = bestFixLocationView{
+			FileName:        location.FileName,
+			Line:            location.Line,
+			Column:          location.Column,
+			Name:            location.Name,
+			Vulnerabilities: strings.Join(location.Queries, ", "),
+			Results:         location.Results,
+		}
+	}
+	fmt.Printf("\n            Best Fix Locations:                     \n")
+	return printer.Print(os.Stdout, views, printer.FormatTable)
+}
@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"log"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const failedGettingGuidance = "Failed getting the learn more guidance"
+
+// newGuidanceEnricher adds the learn more description and the CodeBashing lesson of its query to every SAST result.
+// Each query is looked up once, even when several results share it. A missing lesson is not an error.
+func newGuidanceEnricher(
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+) resultsEnricher {
+	return func(results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error {
+		if results == nil {
+			return nil
+		}
+		var queryIDs []string
+		queries := make(map[string]*wrappers.ScanResult)
+		counts := make(map[string]int)
+		for _, result := range results.Results {
+			if !isGuidedResult(result) {
+				continue
+			}
+			queryID := wrappers.FormatQueryID(result.ScanResultData.QueryID)
+			if _, found := queries[queryID]; !found {
+				queries[queryID] = result
+				queryIDs = append(queryIDs, queryID)
+			}
+			counts[queryID]++
+		}
+		if len(queryIDs) == 0 {
+			return nil
+		}
+
+		descriptions, webError, err := learnMoreWrapper.GetLearnMoreDetails(
+			map[string]string{commonParams.IDsQueryParam: strings.Join(queryIDs, ",")},
+		)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingGuidance)
+		}
+		if webError != nil {
+			return errors.Errorf(ErrorCodeFormat, failedGettingGuidance, webError.Code, webError.Message)
+		}
+		guidance := make(map[string]*wrappers.ResultGuidance, len(queryIDs))
+		if descriptions != nil {
+			for _, description := range *descriptions {
+				if description == nil {
+					continue
+				}
+				guidance[description.QueryID] = &wrappers.ResultGuidance{
+					Risk:                   description.Risk,
+					Cause:                  description.Cause,
+					GeneralRecommendations: description.GeneralRecommendations,
+					Samples:                description.Samples,
+				}
+			}
+		}
+
+		lessons := getCodeBashingLessons(codeBashingWrapper, queryIDs, queries)
+		for i, queryID := range queryIDs {
+			if lessons[i] == "" {
+				continue
+			}
+			if guidance[queryID] == nil {
+				guidance[queryID] = &wrappers.ResultGuidance{}
+			}
+			guidance[queryID].CodeBashingURL = lessons[i]
+		}
+
+		for _, result := range results.Results {
+			if isGuidedResult(result) {
+				result.Guidance = guidance[wrappers.FormatQueryID(result.ScanResultData.QueryID)]
+			}
+		}
+		for _, queryID := range queryIDs {
+			if guidance[queryID] != nil {
+				summary.Guidance = append(
+					summary.Guidance,
+					toGuidanceSummary(queries[queryID], guidance[queryID], counts[queryID]),
+				)
+			}
+		}
+		return nil
+	}
+}
+
+func isGuidedResult(result *wrappers.ScanResult) bool {
+	return result.Type == commonParams.SastType && result.ScanResultData.QueryID != nil
+}
+
+// getCodeBashingLessons returns the lesson URL of every query, empty when there is none. The lessons are optional,
+// so the failures are only logged.
+func getCodeBashingLessons(
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+	queryIDs []string,
+	queries map[string]*wrappers.ScanResult,
+) []string {
+	lessons := make([]string, len(queryIDs))
+	codeBashingURL, err := codeBashingWrapper.GetCodeBashingURL(codeBashingKey)
+	if err != nil {
+		log.Printf("Skipping the CodeBashing lessons: %s", err)
+		return lessons
+	}
+	runConcurrently(
+		len(queryIDs), commonParams.ConcurrencyDefault, 0, func(i int) {
+			lesson, lessonErr := getCodeBashingLesson(codeBashingWrapper, codeBashingURL, queries[queryIDs[i]])
+			if lessonErr != nil {
+				log.Printf("No CodeBashing lesson for query %s: %s", queryIDs[i], lessonErr)
+				return
+			}
+			lessons[i] = lesson
+		},
+	)
+	return lessons
+}
+
+func getCodeBashingLesson(
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+	codeBashingURL string,
+	result *wrappers.ScanResult,
+) (string, error) {
+	params, err := codeBashingWrapper.BuildCodeBashingParams(
+		[]wrappers.CodeBashingParamsCollection{
+			{
+				CweID:       cwePrefix + resultCwe(result),
+				Language:    result.ScanResultData.LanguageName,
+				CxQueryName: strings.ReplaceAll(result.ScanResultData.QueryName, " ", "_"),
+			},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	lessons, webError, err := codeBashingWrapper.GetCodeBashingLinks(params, codeBashingURL)
+	if err != nil {
+		return "", err
+	}
+	if webError != nil {
+		return "", errors.New(webError.Message)
+	}
+	if lessons == nil || len(*lessons) == 0 {
+		return "", nil
+	}
+	return (*lessons)[0].Path, nil
+}
+
+func toGuidanceSummary(result *wrappers.ScanResult, guidance *wrappers.ResultGuidance, count int) *wrappers.GuidanceSummary {
+	name := strings.ReplaceAll(result.ScanResultData.QueryName, "_", " ")
+	if name == "" {
+		name = wrappers.FormatQueryID(result.ScanResultData.QueryID)
+	}
+	return &wrappers.GuidanceSummary{
+		QueryName:              name,
+		Language:               result.ScanResultData.LanguageName,
+		Results:                count,
+		Risk:                   guidance.Risk,
+		GeneralRecommendations: guidance.GeneralRecommendations,
+		CodeBashingURL:         guidance.CodeBashingURL,
+	}
+}
+
+// guidanceEnrichers returns the guidance enricher when --enrich is set. The descriptions go through the on-disk
+// cache shared with utils learn-more.
+func guidanceEnrichers(
+	cmd *cobra.Command,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+) []resultsEnricher {
+	if enrich, _ := cmd.Flags().GetBool(commonParams.EnrichFlag); !enrich {
+		return nil
+	}
+	return []resultsEnricher{newGuidanceEnricher(util.NewDescriptionsCache(learnMoreWrapper, false), codeBashingWrapper)}
+}
@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	failedReadingSnippets = "Failed reading the code snippets"
+	snippetLineMarker     = ">"
+)
+
+// sourceFiles reads the scanned files from the local checkout, once each, and warns once about every missing or
+// changed file
+type sourceFiles struct {
+	dir          string
+	contextLines int
+	lines        map[string][]string
+	warned       map[string]bool
+}
+
+// newSnippetEnricher adds the code around every SAST node, KICS line and SCA manifest location, read from sourceDir.
+// A missing file or a line that no longer matches the result is reported as a warning, not an error.
+func newSnippetEnricher(sourceDir string, contextLines int) resultsEnricher {
+	return func(results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error {
+		if results == nil {
+			return nil
+		}
+		info, err := os.Stat(sourceDir)
+		if err != nil || !info.IsDir() {
+			return errors.Errorf("%s: %s is not a directory", failedReadingSnippets, sourceDir)
+		}
+		sources := &sourceFiles{
+			dir:          sourceDir,
+			contextLines: contextLines,
+			lines:        make(map[string][]string),
+			warned:       make(map[string]bool),
+		}
+		for _, result := range results.Results {
+			var main *wrappers.CodeSnippet
+			switch result.Type {
+			case commonParams.SastType:
+				for _, node := range result.ScanResultData.Nodes {
+					if node == nil {
+						continue
+					}
+					node.Snippet = sources.snippet(node.FileName, node.Line, node.Name)
+					if main == nil {
+						main = node.Snippet
+					}
+				}
+			case commonParams.KicsType:
+				result.ScanResultData.Snippet = sources.snippet(result.ScanResultData.Filename, result.ScanResultData.Line, "")
+				main = result.ScanResultData.Snippet
+			case commonParams.ScaType:
+				packageCollection := result.ScanResultData.ScaPackageCollection
+				if packageCollection == nil {
+					continue
+				}
+				packageCollection.Snippets = nil
+				for _, location := range packageCollection.Locations {
+					if location == nil {
+						continue
+					}
+					if snippet := sources.manifestSnippet(*location, scaPackageName(result)); snippet != nil {
+						packageCollection.Snippets = append(packageCollection.Snippets, snippet)
+					}
+				}
+				if len(packageCollection.Snippets) > 0 {
+					main = packageCollection.Snippets[0]
+				}
+			}
+			if main != nil {
+				summary.Snippets = append(summary.Snippets, toSnippetSummary(result, main))
+			}
+		}
+		return nil
+	}
+}
+
+// snippet returns the code around line, changed when the line does not contain the code element anymore
+func (s *sourceFiles) snippet(fileName string, line uint, element string) *wrappers.CodeSnippet {
+	if fileName == "" || line == 0 {
+		return nil
+	}
+	lines := s.read(fileName)
+	if lines == nil {
+		return nil
+	}
+	if int(line) > len(lines) {
+		s.warnChanged(fileName)
+		return nil
+	}
+	snippet := s.around(fileName, lines, line)
+	if element != "" && !strings.Contains(lines[line-1], element) {
+		snippet.Changed = true
+		s.warnChanged(fileName)
+	}
+	return snippet
+}
+
+// manifestSnippet returns the code around the first line of the manifest mentioning the package
+func (s *sourceFiles) manifestSnippet(fileName, packageName string) *wrappers.CodeSnippet {
+	lines := s.read(fileName)
+	if lines == nil || packageName == "" {
+		return nil
+	}
+	for i, code := range lines {
+		if strings.Contains(code, packageName) {
+			return s.around(fileName, lines, uint(i+1))
+		}
+	}
+	s.warnChanged(fileName)
+	return nil
+}
+
+func (s *sourceFiles) around(fileName string, lines []string, line uint) *wrappers.CodeSnippet {
+	start := int(line) - s.contextLines
+	if start < 1 {
+		start = 1
+	}
+	end := int(line) + s.contextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return &wrappers.CodeSnippet{
+		FileName:  fileName,
+		Line:      line,
+		StartLine: uint(start),
+		Lines:     lines[start-1 : end],
+	}
+}
+
+// read returns the lines of the file, nil when it is missing or outside of the source directory
+func (s *sourceFiles) read(fileName string) []string {
+	if lines, found := s.lines[fileName]; found {
+		return lines
+	}
+	var lines []string
+	path := filepath.Join(s.dir, filepath.FromSlash(strings.TrimLeft(fileName, "/")))
+	relative, err := filepath.Rel(s.dir, path)
+	if err == nil && !strings.HasPrefix(relative, "..") {
+		var content []byte
+		if content, err = ioutil.ReadFile(path); err == nil {
+			text := strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+			lines = strings.Split(text, "\n")
+		}
+	}
+	if lines == nil {
+		log.Printf("Warning: %s was not found in %s\n", fileName, s.dir)
+	}
+	s.lines[fileName] = lines
+	return lines
+}
+
+func (s *sourceFiles) warnChanged(fileName string) {
+	if !s.warned[fileName] {
+		s.warned[fileName] = true
+		log.Printf("Warning: %s appears to have changed since the scan\n", fileName)
+	}
+}
+
+// scaPackageName returns the name of the vulnerable package, as written in the manifests
+func scaPackageName(result *wrappers.ScanResult) string {
+	packageCollection := result.ScanResultData.ScaPackageCollection
+	for _, dependencyPath := range packageCollection.DependencyPathArray {
+		if len(dependencyPath) > 0 && dependencyPath[0].Name != "" {
+			return dependencyPath[0].Name
+		}
+	}
+	return ""
+}
+
+func toSnippetSummary(result *wrappers.ScanResult, snippet *wrappers.CodeSnippet) *wrappers.SnippetSummary {
+	return &wrappers.SnippetSummary{
+		Type:     result.Type,
+		Severity: result.Severity,
+		Name:     resultDisplayName(result),
+		FileName: snippet.FileName,
+		Line:     snippet.Line,
+		Code:     formatSnippet(snippet),
+		Changed:  snippet.Changed,
+	}
+}
+
+func resultDisplayName(result *wrappers.ScanResult) string {
+	if result.ScanResultData.QueryName != "" {
+		return strings.ReplaceAll(result.ScanResultData.QueryName, "_", " ")
+	}
+	if result.ScanResultData.PackageIdentifier != "" {
+		return result.ScanResultData.PackageIdentifier
+	}
+	if result.VulnerabilityDetails.CveName != "" {
+		return result.VulnerabilityDetails.CveName
+	}
+	return result.SimilarityID
+}
+
+// formatSnippet numbers the lines of the snippet and marks the result line
+func formatSnippet(snippet *wrappers.CodeSnippet) string {
+	var code strings.Builder
+	width := len(fmt.Sprint(snippet.StartLine + uint(len(snippet.Lines))))
+	for i, line := range snippet.Lines {
+		number := snippet.StartLine + uint(i)
+		marker := " "
+		if number == snippet.Line {
+			marker = snippetLineMarker
+		}
+		code.WriteString(fmt.Sprintf("%s %*d | %s\n", marker, width, number, line))
+	}
+	return code.String()
+}
+
+func writeConsoleSnippets(summary *wrappers.ResultSummary) {
+	if len(summary.Snippets) == 0 {
+		return
+	}
+	fmt.Printf("\n            Code Snippets:                     \n")
+	for _, snippet := range summary.Snippets {
+		changed := ""
+		if snippet.Changed {
+			changed = " (changed since the scan)"
+		}
+		fmt.Printf(
+			"\n  %s %s: %s\n  %s:%d%s\n",
+			strings.ToUpper(snippet.Severity), snippet.Type, snippet.Name, snippet.FileName, snippet.Line, changed,
+		)
+		for _, line := range strings.Split(strings.TrimSuffix(snippet.Code, "\n"), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+// exportMarkdownResults writes the results and their code snippets, if any, as a Markdown document
+func exportMarkdownResults(targetFile string, results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error {
+	log.Println("Creating Markdown Report: ", targetFile)
+	var markdown strings.Builder
+	markdown.WriteString("# Checkmarx Scan Results\n\n")
+	markdown.WriteString(fmt.Sprintf("- Project: %s\n", summary.ProjectName))
+	markdown.WriteString(fmt.Sprintf("- Scan ID: %s\n", summary.ScanID))
+	markdown.WriteString(
+		fmt.Sprintf(
+			"- Results: %d (High: %d, Medium: %d, Low: %d)\n",
+			summary.TotalIssues, summary.HighIssues, summary.MediumIssues, summary.LowIssues,
+		),
+	)
+	for i, result := range results.Results {
+		markdown.WriteString(
+			fmt.Sprintf(
+				"\n## %d. %s %s: %s\n\n",
+				i+1, strings.ToUpper(result.Severity), result.Type, resultDisplayName(result),
+			),
+		)
+		if result.State != "" {
+			markdown.WriteString(fmt.Sprintf("State: %s\n\n", result.State))
+		}
+		switch result.Type {
+		case commonParams.SastType:
+			for _, node := range result.ScanResultData.Nodes {
+				if node != nil {
+					writeMarkdownLocation(&markdown, node.FileName, node.Line, node.Name, node.Snippet)
+				}
+			}
+		case commonParams.KicsType:
+			writeMarkdownLocation(
+				&markdown, result.ScanResultData.Filename, result.ScanResultData.Line, "", result.ScanResultData.Snippet,
+			)
+		case commonParams.ScaType:
+			if packageCollection := result.ScanResultData.ScaPackageCollection; packageCollection != nil {
+				for _, snippet := range packageCollection.Snippets {
+					writeMarkdownLocation(&markdown, snippet.FileName, snippet.Line, "", snippet)
+				}
+			}
+		}
+	}
+	f, err := os.Create(targetFile)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to create target file  ", failedGettingAll)
+	}
+	_, err = fmt.Fprint(f, markdown.String())
+	if err != nil {
+		_ = f.Close()
+		return errors.Wrapf(err, "%s: failed to write target file", failedGettingAll)
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrapf(err, "%s: failed to write target file", failedGettingAll)
+	}
+	return nil
+}
+
+func writeMarkdownLocation(markdown *strings.Builder, fileName string, line uint, element string, snippet *wrappers.CodeSnippet) {
+	if fileName == "" {
+		return
+	}
+	location := fmt.Sprintf("- `%s:%d`", fileName, line)
+	if element != "" {
+		location += fmt.Sprintf(" %s", element)
+	}
+	if snippet != nil && snippet.Changed {
+		location += " (changed since the scan)"
+	}
+	markdown.WriteString(location + "\n")
+	if snippet != nil {
+		markdown.WriteString("\n```\n" + formatSnippet(snippet) + "```\n\n")
+	}
+}
@@ -0,0 +1,383 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedTracingResults = "Failed tracing the results"
+	traceFormatDOT       = "dot"
+	traceFormatMermaid   = "mermaid"
+	traceGraphPath       = "path"
+	traceGraphBfl        = "bfl"
+	traceFilePermission  = 0600
+)
+
+var traceFileExtensions = map[string]string{
+	traceFormatDOT:     "dot",
+	traceFormatMermaid: "mmd",
+	printer.FormatJSON: "json",
+}
+
+// traceGraph is the attack vector of a result, either its data-flow path or the best fix location trees of its query
+type traceGraph struct {
+	ResultID string       `json:"resultId"`
+	Query    string       `json:"query,omitempty"`
+	Graph    string       `json:"graph"`
+	Nodes    []*traceNode `json:"nodes"`
+	Edges    []*traceEdge `json:"edges"`
+}
+
+type traceNode struct {
+	ID       string `json:"id"`
+	Label    string `json:"label"`
+	FileName string `json:"fileName,omitempty"`
+	Line     uint   `json:"line,omitempty"`
+	Column   uint   `json:"column,omitempty"`
+	Method   string `json:"method,omitempty"`
+	Name     string `json:"name,omitempty"`
+	BestFix  bool   `json:"bestFix,omitempty"`
+}
+
+type traceEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func resultTraceSubCommand(resultsWrapper wrappers.ResultsWrapper, bflWrapper wrappers.BflWrapper) *cobra.Command {
+	resultTraceCmd := &cobra.Command{
+		Use:   "trace",
+		Short: "Export the attack vector of SAST results as a graph",
+		Long: "The trace command exports the data-flow path or the best fix location tree of SAST results as " +
+			"Graphviz DOT, Mermaid or a JSON graph. Without --result-id every SAST result of the scan is exported.",
+		Example: heredoc.Doc(
+			`
+			$ cx results trace --scan-id <scan Id> --result-id <result Id> | dot -Tsvg -o trace.svg
+			$ cx results trace --scan-id <scan Id> --result-id <result Id> --graph bfl --format mermaid
+			$ cx results trace --scan-id <scan Id> --format json --output-path traces
+		`,
+		),
+		RunE: runGetResultTraceCommand(resultsWrapper, bflWrapper),
+	}
+	addScanIDFlag(resultTraceCmd, "ID to report on.")
+	resultTraceCmd.PersistentFlags().StringSlice(commonParams.ResultIDFlag, []string{}, commonParams.ResultIDFlagUsage)
+	resultTraceCmd.PersistentFlags().String(commonParams.TraceGraphFlag, traceGraphPath, commonParams.TraceGraphFlagUsage)
+	resultTraceCmd.PersistentFlags().String(commonParams.TargetPathFlag, "", commonParams.TraceOutputPathFlagUsage)
+	addFormatFlag(resultTraceCmd, traceFormatDOT, traceFormatMermaid, printer.FormatJSON)
+	markFlagAsRequired(resultTraceCmd, commonParams.ScanIDFlag)
+	return resultTraceCmd
+}
+
+func runGetResultTraceCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	bflWrapper wrappers.BflWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		resultIDs, _ := cmd.Flags().GetStringSlice(commonParams.ResultIDFlag)
+		graphType, _ := cmd.Flags().GetString(commonParams.TraceGraphFlag)
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+		outputPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+		format = strings.ToLower(format)
+		graphType = strings.ToLower(graphType)
+		if _, found := traceFileExtensions[format]; !found {
+			return errors.Errorf("%s: Invalid format %s", failedTracingResults, format)
+		}
+		if graphType != traceGraphPath && graphType != traceGraphBfl {
+			return errors.Errorf(
+				"%s: Invalid --%s %s, expected %s or %s",
+				failedTracingResults, commonParams.TraceGraphFlag, graphType, traceGraphPath, traceGraphBfl,
+			)
+		}
+
+		results, err := getTracedResults(resultsWrapper, scanID, resultIDs)
+		if err != nil {
+			return err
+		}
+		var trees map[string][]wrappers.BFLTreeModel
+		if graphType == traceGraphBfl {
+			var queryIDs []string
+			for _, result := range results {
+				queryIDs = append(queryIDs, wrappers.FormatQueryID(result.ScanResultData.QueryID))
+			}
+			trees, err = getQueriesBflTrees(bflWrapper, scanID, wrappers.DistinctQueryIDs(queryIDs))
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedTracingResults)
+			}
+		}
+		graphs := make([]*traceGraph, len(results))
+		for i, result := range results {
+			if graphType == traceGraphBfl {
+				graphs[i], err = toBflTraceGraph(trees[wrappers.FormatQueryID(result.ScanResultData.QueryID)], result)
+			} else {
+				graphs[i] = toPathTraceGraph(result)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "%s", failedTracingResults)
+			}
+		}
+
+		if outputPath != "" {
+			return writeTraceFiles(outputPath, format, graphs)
+		}
+		if format == printer.FormatJSON {
+			return printer.Print(cmd.OutOrStdout(), graphs, printer.FormatJSON)
+		}
+		for i, graph := range graphs {
+			if i > 0 {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			}
+			writeTraceGraph(cmd.OutOrStdout(), format, graph)
+		}
+		return nil
+	}
+}
+
+// getTracedResults returns the SAST results with the ids, in their order, or every SAST result of the scan
+func getTracedResults(resultsWrapper wrappers.ResultsWrapper, scanID string, resultIDs []string) ([]*wrappers.ScanResult, error) {
+	resultsModel, webError, err := wrappers.NewAllPagesResultsWrapper(resultsWrapper, commonParams.ConcurrencyDefault).
+		GetAllResultsByScanID(
+			map[string]string{
+				commonParams.ScanIDQueryParam:       scanID,
+				commonParams.IncludeNodesQueryParam: "true",
+			},
+		)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedTracingResults)
+	}
+	if webError != nil {
+		return nil, errors.Errorf(ErrorCodeFormat, failedTracingResults, webError.Code, webError.Message)
+	}
+	var scanResults []*wrappers.ScanResult
+	if resultsModel != nil {
+		scanResults = resultsModel.Results
+	}
+
+	if len(resultIDs) == 0 {
+		var traced []*wrappers.ScanResult
+		for _, result := range scanResults {
+			if result.Type == commonParams.SastType {
+				traced = append(traced, result)
+			}
+		}
+		if len(traced) == 0 {
+			return nil, errors.Errorf("%s: The scan %s has no SAST results", failedTracingResults, scanID)
+		}
+		return traced, nil
+	}
+	byID := make(map[string]*wrappers.ScanResult, len(scanResults))
+	for _, result := range scanResults {
+		byID[result.ID] = result
+	}
+	traced := make([]*wrappers.ScanResult, len(resultIDs))
+	for i, resultID := range resultIDs {
+		result, found := byID[resultID]
+		if !found {
+			return nil, errors.Errorf("%s: Result %s not found in scan %s", failedTracingResults, resultID, scanID)
+		}
+		if result.Type != commonParams.SastType {
+			return nil, errors.Errorf("%s: Result %s is not a SAST result", failedTracingResults, resultID)
+		}
+		traced[i] = result
+	}
+	return traced, nil
+}
+
+// toPathTraceGraph links the nodes of the result in their data-flow order, from the source to the sink
+func toPathTraceGraph(result *wrappers.ScanResult) *traceGraph {
+	graph := newTraceGraph(result, traceGraphPath)
+	for i, node := range result.ScanResultData.Nodes {
+		graph.Nodes = append(graph.Nodes, toTraceNode(fmt.Sprintf("n%d", i), node))
+		if i > 0 {
+			graph.Edges = append(graph.Edges, &traceEdge{From: fmt.Sprintf("n%d", i-1), To: fmt.Sprintf("n%d", i)})
+		}
+	}
+	return graph
+}
+
+// toBflTraceGraph merges the best fix location trees of the result query which contain the result
+func toBflTraceGraph(trees []wrappers.BFLTreeModel, result *wrappers.ScanResult) (*traceGraph, error) {
+	graph := newTraceGraph(result, traceGraphBfl)
+	ids := make(map[string]string)
+	nodeID := func(treeNodeID string) string {
+		if _, found := ids[treeNodeID]; !found {
+			ids[treeNodeID] = fmt.Sprintf("n%d", len(ids))
+		}
+		return ids[treeNodeID]
+	}
+	for i := range trees {
+		tree := &trees[i]
+		if !bflTreeContains(tree, result) {
+			continue
+		}
+		treeNodeIDs := make([]string, 0, len(tree.Nodes))
+		for treeNodeID := range tree.Nodes {
+			treeNodeIDs = append(treeNodeIDs, treeNodeID)
+		}
+		sort.Strings(treeNodeIDs)
+		for _, treeNodeID := range treeNodeIDs {
+			if _, found := ids[treeNodeID]; found || tree.Nodes[treeNodeID] == nil {
+				continue
+			}
+			node := toTraceNode(nodeID(treeNodeID), tree.Nodes[treeNodeID])
+			node.BestFix = tree.BFL != nil && isSameLocation(tree.Nodes[treeNodeID], tree.BFL)
+			graph.Nodes = append(graph.Nodes, node)
+		}
+		for _, pair := range tree.NodesAdjacencyPairs {
+			if len(pair) == 2 && tree.Nodes[pair[0]] != nil && tree.Nodes[pair[1]] != nil {
+				graph.Edges = append(graph.Edges, &traceEdge{From: nodeID(pair[0]), To: nodeID(pair[1])})
+			}
+		}
+	}
+	if len(graph.Nodes) == 0 {
+		return nil, errors.Errorf("No best fix location tree found for result %s", result.ID)
+	}
+	return graph, nil
+}
+
+// bflTreeContains tells if the tree covers the result, every tree does when the result has no hash to match
+func bflTreeContains(tree *wrappers.BFLTreeModel, result *wrappers.ScanResult) bool {
+	if result.ScanResultData.ResultHash == "" {
+		return true
+	}
+	for _, data := range tree.Results {
+		if data != nil && data.ResultHash == result.ScanResultData.ResultHash {
+			return true
+		}
+	}
+	return false
+}
+
+func isSameLocation(node, other *wrappers.ScanResultNode) bool {
+	return node.FileName == other.FileName && node.Line == other.Line && node.Column == other.Column
+}
+
+func newTraceGraph(result *wrappers.ScanResult, graphType string) *traceGraph {
+	return &traceGraph{
+		ResultID: result.ID,
+		Query:    strings.ReplaceAll(result.ScanResultData.QueryName, "_", " "),
+		Graph:    graphType,
+		Nodes:    []*traceNode{},
+		Edges:    []*traceEdge{},
+	}
+}
+
+func toTraceNode(id string, node *wrappers.ScanResultNode) *traceNode {
+	labels := []string{fmt.Sprintf("%s:%d", node.FileName, node.Line)}
+	if node.Method != "" {
+		labels = append(labels, node.Method)
+	}
+	if node.Name != "" {
+		labels = append(labels, node.Name)
+	}
+	return &traceNode{
+		ID:       id,
+		Label:    strings.Join(labels, "\n"),
+		FileName: node.FileName,
+		Line:     node.Line,
+		Column:   node.Column,
+		Method:   node.Method,
+		Name:     node.Name,
+	}
+}
+
+func writeTraceFiles(outputPath, format string, graphs []*traceGraph) error {
+	err := createDirectory(outputPath)
+	if err != nil {
+		return err
+	}
+	for i, graph := range graphs {
+		// the result ids are base64 and may contain a slash
+		name := url.PathEscape(graph.ResultID)
+		if name == "" {
+			name = fmt.Sprintf("result-%d", i+1)
+		}
+		target := filepath.Join(outputPath, name+"."+traceFileExtensions[format])
+		var content strings.Builder
+		if format == printer.FormatJSON {
+			data, marshalErr := json.Marshal(graph)
+			if marshalErr != nil {
+				return errors.Wrapf(marshalErr, "%s", failedTracingResults)
+			}
+			content.Write(data)
+		} else {
+			writeTraceGraph(&content, format, graph)
+		}
+		log.Println("Creating trace: ", target)
+		if err = ioutil.WriteFile(target, []byte(content.String()), traceFilePermission); err != nil {
+			return errors.Wrapf(err, "%s", failedTracingResults)
+		}
+	}
+	return nil
+}
+
+func writeTraceGraph(w io.Writer, format string, graph *traceGraph) {
+	if format == traceFormatMermaid {
+		writeMermaidGraph(w, graph)
+	} else {
+		writeDOTGraph(w, graph)
+	}
+}
+
+func writeDOTGraph(w io.Writer, graph *traceGraph) {
+	_, _ = fmt.Fprintf(w, "digraph %s {\n", dotQuote(graph.ResultID))
+	if graph.Query != "" {
+		_, _ = fmt.Fprintf(w, "  label=%s;\n", dotQuote(graph.Query))
+	}
+	_, _ = fmt.Fprintln(w, "  node [shape=box];")
+	for _, node := range graph.Nodes {
+		style := ""
+		if node.BestFix {
+			style = ", color=red, penwidth=2"
+		}
+		_, _ = fmt.Fprintf(w, "  %s [label=%s%s];\n", node.ID, dotQuote(node.Label), style)
+	}
+	for _, edge := range graph.Edges {
+		_, _ = fmt.Fprintf(w, "  %s -> %s;\n", edge.From, edge.To)
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+func writeMermaidGraph(w io.Writer, graph *traceGraph) {
+	_, _ = fmt.Fprintln(w, "flowchart TD")
+	for _, node := range graph.Nodes {
+		_, _ = fmt.Fprintf(w, "  %s[\"%s\"]\n", node.ID, mermaidEscape(node.Label))
+	}
+	for _, edge := range graph.Edges {
+		_, _ = fmt.Fprintf(w, "  %s --> %s\n", edge.From, edge.To)
+	}
+	for _, node := range graph.Nodes {
+		if node.BestFix {
+			_, _ = fmt.Fprintf(w, "  style %s stroke:#d00,stroke-width:3px\n", node.ID)
+		}
+	}
+}
+
+func dotQuote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + strings.ReplaceAll(value, "\n", `\n`) + `"`
+}
+
+func mermaidEscape(value string) string {
+	value = strings.ReplaceAll(value, "&", "#amp;")
+	value = strings.ReplaceAll(value, `"`, "#quot;")
+	value = strings.ReplaceAll(value, "<", "#lt;")
+	value = strings.ReplaceAll(value, ">", "#gt;")
+	return strings.ReplaceAll(value, "\n", "<br/>")
+}
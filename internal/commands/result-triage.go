@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+const (
+	failedGettingTriageHistory = "Failed getting the triage history"
+	triageHistoryTimeFormat    = "2006-01-02, 15:04:05"
+)
+
+// newTriageHistoryEnricher adds the predicate history to every SAST and KICS result. The history of each
+// similarity ID is requested once, even when several results share it.
+func newTriageHistoryEnricher(resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper) resultsEnricher {
+	return func(results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error {
+		if results == nil {
+			return nil
+		}
+		cache := make(map[string][]wrappers.ResultTriage)
+		var pending []*wrappers.ScanResult
+		for _, result := range results.Results {
+			key := triageHistoryKey(result)
+			if _, found := cache[key]; found || !isTriageableResult(result) {
+				continue
+			}
+			cache[key] = nil
+			pending = append(pending, result)
+		}
+
+		histories := make([][]wrappers.ResultTriage, len(pending))
+		errs := make([]error, len(pending))
+		runConcurrently(
+			len(pending), params.ConcurrencyDefault, 0, func(i int) {
+				histories[i], errs[i] = getTriageHistory(resultsPredicatesWrapper, summary.ProjectID, pending[i])
+			},
+		)
+		for i, result := range pending {
+			if errs[i] != nil {
+				return errors.Wrapf(errs[i], "%s", failedGettingTriageHistory)
+			}
+			cache[triageHistoryKey(result)] = histories[i]
+		}
+
+		for _, result := range results.Results {
+			if !isTriageableResult(result) {
+				continue
+			}
+			result.TriageHistory = cache[triageHistoryKey(result)]
+			if len(result.TriageHistory) > 0 {
+				summary.TriagedResults = append(summary.TriagedResults, toTriagedResultSummary(result))
+			}
+		}
+		return nil
+	}
+}
+
+func triageHistoryKey(result *wrappers.ScanResult) string {
+	return strings.ToLower(result.Type) + "/" + result.SimilarityID
+}
+
+// getTriageHistory returns the predicates of the result in the project, oldest first
+func getTriageHistory(
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	projectID string,
+	result *wrappers.ScanResult,
+) ([]wrappers.ResultTriage, error) {
+	predicates, errorModel, err := resultsPredicatesWrapper.GetAllPredicatesForSimilarityID(
+		result.SimilarityID,
+		projectID,
+		strings.ToLower(result.Type),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	if predicates == nil {
+		return nil, nil
+	}
+	var projectPredicates []wrappers.Predicate
+	for _, history := range predicates.PredicateHistoryPerProject {
+		if history.ProjectID == "" || history.ProjectID == projectID {
+			projectPredicates = append(projectPredicates, history.Predicates...)
+		}
+	}
+	sort.SliceStable(
+		projectPredicates, func(i, j int) bool {
+			return projectPredicates[i].CreatedAt.Before(projectPredicates[j].CreatedAt)
+		},
+	)
+	history := make([]wrappers.ResultTriage, len(projectPredicates))
+	for i := range projectPredicates {
+		predicate := &projectPredicates[i]
+		history[i] = wrappers.ResultTriage{
+			State:     predicate.State,
+			Severity:  predicate.Severity,
+			Comment:   predicate.Comment,
+			CreatedBy: predicate.CreatedBy,
+			CreatedAt: predicate.CreatedAt,
+		}
+		if i > 0 {
+			history[i].PreviousState = history[i-1].State
+			history[i].PreviousSeverity = history[i-1].Severity
+		}
+	}
+	return history, nil
+}
+
+func toTriagedResultSummary(result *wrappers.ScanResult) *wrappers.TriagedResultSummary {
+	latest := result.TriageHistory[len(result.TriageHistory)-1]
+	name := result.ScanResultData.QueryName
+	if name == "" {
+		name = result.SimilarityID
+	}
+	return &wrappers.TriagedResultSummary{
+		Type:      result.Type,
+		Name:      name,
+		Severity:  latest.Severity,
+		State:     latest.State,
+		Comment:   latest.Comment,
+		CreatedBy: latest.CreatedBy,
+		CreatedAt: latest.CreatedAt.Format(triageHistoryTimeFormat),
+		Changes:   len(result.TriageHistory),
+	}
+}
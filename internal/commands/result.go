@@ -76,11 +76,16 @@ var securities = map[string]string{
 	highCx:   "9.5",
 }
 
+// resultsEnricher adds information to the results and the summary before the reports are written
+type resultsEnricher func(results *wrappers.ScanResultsCollection, summary *wrappers.ResultSummary) error
+
 func NewResultsCommand(
 	resultsWrapper wrappers.ResultsWrapper,
 	scanWrapper wrappers.ScansWrapper,
 	codeBashingWrapper wrappers.CodeBashingWrapper,
 	bflWrapper wrappers.BflWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
 ) *cobra.Command {
 	resultCmd := &cobra.Command{
 		Use:   "results",
@@ -93,16 +98,31 @@ func NewResultsCommand(
 			),
 		},
 	}
-	showResultCmd := resultShowSubCommand(resultsWrapper, scanWrapper)
+	showResultCmd := resultShowSubCommand(
+		resultsWrapper,
+		scanWrapper,
+		resultsPredicatesWrapper,
+		learnMoreWrapper,
+		codeBashingWrapper,
+		bflWrapper,
+	)
 	codeBashingCmd := resultCodeBashing(codeBashingWrapper)
 	bflResultCmd := resultBflSubCommand(bflWrapper)
+	traceResultCmd := resultTraceSubCommand(resultsWrapper, bflWrapper)
 	resultCmd.AddCommand(
-		showResultCmd, bflResultCmd, codeBashingCmd,
+		showResultCmd, bflResultCmd, codeBashingCmd, traceResultCmd,
 	)
 	return resultCmd
 }
 
-func resultShowSubCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wrappers.ScansWrapper) *cobra.Command {
+func resultShowSubCommand(
+	resultsWrapper wrappers.ResultsWrapper,
+	scanWrapper wrappers.ScansWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+	bflWrapper wrappers.BflWrapper,
+) *cobra.Command {
 	resultShowCmd := &cobra.Command{
 		Use:   "show",
 		Short: "Show results of a scan",
@@ -110,9 +130,21 @@ func resultShowSubCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wr
 		Example: heredoc.Doc(
 			`
 			$ cx results show --scan-id <scan Id>
+			$ cx results show --scan-id <scan Id> --report-format json,summaryHTML --include-triage-history
+			$ cx results show --scan-id <scan Id> --all
+			$ cx results show --scan-id <scan Id> --report-format sarif,summaryHTML --enrich
+			$ cx results show --scan-id <scan Id> --report-format summaryConsole,summaryHTML --group-by bfl
+			$ cx results show --scan-id <scan Id> --report-format summaryConsole,markdown --source-dir .
 		`,
 		),
-		RunE: runGetResultCommand(resultsWrapper, scanWrapper),
+		RunE: runGetResultCommand(
+			resultsWrapper,
+			scanWrapper,
+			resultsPredicatesWrapper,
+			learnMoreWrapper,
+			codeBashingWrapper,
+			bflWrapper,
+		),
 	}
 	addScanIDFlag(resultShowCmd, "ID to report on.")
 	addResultFormatFlag(
@@ -122,10 +154,25 @@ func resultShowSubCommand(resultsWrapper wrappers.ResultsWrapper, scanWrapper wr
 		printer.FormatSummaryConsole,
 		printer.FormatSarif,
 		printer.FormatSummaryJSON,
+		printer.FormatMarkdown,
 	)
 	resultShowCmd.PersistentFlags().String(commonParams.TargetFlag, "cx_result", "Output file")
 	resultShowCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
 	resultShowCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	resultShowCmd.PersistentFlags().Bool(
+		commonParams.TriageHistoryFlag,
+		false,
+		commonParams.TriageHistoryFlagUsage,
+	)
+	resultShowCmd.PersistentFlags().Bool(commonParams.EnrichFlag, false, commonParams.EnrichFlagUsage)
+	resultShowCmd.PersistentFlags().String(commonParams.GroupByFlag, "", commonParams.GroupByFlagUsage)
+	resultShowCmd.PersistentFlags().String(commonParams.SourceDirFlag, "", commonParams.SourceDirFlagUsage)
+	resultShowCmd.PersistentFlags().Int(
+		commonParams.SnippetContextFlag,
+		commonParams.SnippetContextDefault,
+		commonParams.SnippetContextFlagUsage,
+	)
+	addAllPagesFlags(resultShowCmd)
 	return resultShowCmd
 }
 
@@ -396,6 +443,8 @@ func writeConsoleSummary(summary *wrappers.ResultSummary) error {
 		}
 		fmt.Printf("              -----------------------------------     \n")
 		fmt.Printf("              Checkmarx AST - Scan Summary & Details: %s\n", generateScanSummaryURL(summary))
+		writeConsoleSnippets(summary)
+		return writeConsoleBestFixLocations(summary)
 	} else {
 		fmt.Printf("Scan executed in asynchronous mode or still running. Hence, no results generated.\n")
 		fmt.Printf("For more information: %s", summary.BaseURI)
@@ -414,6 +463,10 @@ func generateScanSummaryURL(summary *wrappers.ResultSummary) string {
 func runGetResultCommand(
 	resultsWrapper wrappers.ResultsWrapper,
 	scanWrapper wrappers.ScansWrapper,
+	resultsPredicatesWrapper wrappers.ResultsPredicatesWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
+	bflWrapper wrappers.BflWrapper,
 ) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
@@ -424,7 +477,50 @@ func runGetResultCommand(
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedListingResults)
 		}
-		return CreateScanReport(resultsWrapper, scanWrapper, scanID, format, targetFile, targetPath, params)
+		all, _, concurrency, err := getAllPagesFlags(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedListingResults)
+		}
+		reportWrapper := resultsWrapper
+		if all {
+			reportWrapper = wrappers.NewAllPagesResultsWrapper(resultsWrapper, concurrency)
+		}
+		var enrichers []resultsEnricher
+		if includeTriageHistory, _ := cmd.Flags().GetBool(commonParams.TriageHistoryFlag); includeTriageHistory {
+			enrichers = append(enrichers, newTriageHistoryEnricher(resultsPredicatesWrapper))
+		}
+		enrichers = append(enrichers, guidanceEnrichers(cmd, learnMoreWrapper, codeBashingWrapper)...)
+		groupBy, _ := cmd.Flags().GetString(commonParams.GroupByFlag)
+		switch strings.ToLower(groupBy) {
+		case "":
+		case groupByBfl:
+			enrichers = append(enrichers, newBflGroupEnricher(bflWrapper))
+		default:
+			return errors.Errorf(
+				"%s: Invalid --%s %s, available groupings: %s",
+				failedListingResults,
+				commonParams.GroupByFlag,
+				groupBy,
+				groupByBfl,
+			)
+		}
+		if sourceDir, _ := cmd.Flags().GetString(commonParams.SourceDirFlag); sourceDir != "" {
+			contextLines, _ := cmd.Flags().GetInt(commonParams.SnippetContextFlag)
+			if contextLines < 0 {
+				return errors.Errorf("--%s should be equal or higher than 0", commonParams.SnippetContextFlag)
+			}
+			enrichers = append(enrichers, newSnippetEnricher(sourceDir, contextLines))
+		}
+		return CreateScanReport(
+			reportWrapper,
+			scanWrapper,
+			scanID,
+			format,
+			targetFile,
+			targetPath,
+			params,
+			enrichers...,
+		)
 	}
 }
 
@@ -476,6 +572,7 @@ func CreateScanReport(
 	targetFile,
 	targetPath string,
 	params map[string]string,
+	enrichers ...resultsEnricher,
 ) error {
 	if scanID == "" {
 		return errors.Errorf("%s: Please provide a scan ID", failedListingResults)
@@ -492,6 +589,12 @@ func CreateScanReport(
 	if err != nil {
 		return err
 	}
+	for _, enrich := range enrichers {
+		err = enrich(results, summary)
+		if err != nil {
+			return err
+		}
+	}
 	reportList := strings.Split(reportTypes, ",")
 	for _, reportType := range reportList {
 		err = createReport(reportType, targetFile, targetPath, results, summary)
@@ -540,6 +643,10 @@ func createReport(
 		convertNotAvailableNumberToZero(summary)
 		return writeHTMLSummary(summaryRpt, summary)
 	}
+	if printer.IsFormat(format, printer.FormatMarkdown) {
+		markdownRpt := createTargetName(targetFile, targetPath, "md")
+		return exportMarkdownResults(markdownRpt, results, summary)
+	}
 	if printer.IsFormat(format, printer.FormatSummaryJSON) {
 		summaryRpt := createTargetName(targetFile, targetPath, "json")
 		convertNotAvailableNumberToZero(summary)
@@ -590,6 +697,7 @@ func ReadResults(
 		if errorModel != nil {
 			return nil, errors.Errorf("%s: CODE: %d, %s", failedListingResults, errorModel.Code, errorModel.Message)
 		}
+		warnTruncated("results", params, len(resultsModel.Results), resultsModel.TotalCount)
 		// Enrich sca results
 		if scaPackageModel != nil {
 			resultsModel = addPackageInformation(resultsModel, scaPackageModel)
@@ -846,10 +954,36 @@ func findHelpMarkdownText(result *wrappers.ScanResult) string {
 			result.Description, result.ScanResultData.Value, result.ScanResultData.ExpectedValue,
 		)
 	}
+	if result.Guidance != nil {
+		return result.Description + findGuidanceMarkdownText(result.Guidance)
+	}
 
 	return result.Description
 }
 
+func findGuidanceMarkdownText(guidance *wrappers.ResultGuidance) string {
+	var markdown strings.Builder
+	sections := []struct{ title, text string }{
+		{"Risk", guidance.Risk},
+		{"Cause", guidance.Cause},
+		{"General Recommendations", guidance.GeneralRecommendations},
+	}
+	for _, section := range sections {
+		if section.text != "" {
+			markdown.WriteString(fmt.Sprintf("<br><br><strong>%s:</strong> %s", section.title, section.text))
+		}
+	}
+	for _, sample := range guidance.Samples {
+		markdown.WriteString(
+			fmt.Sprintf("\n\n<strong>%s (%s)</strong>\n```\n%s\n```", sample.Title, sample.ProgLanguage, sample.Code),
+		)
+	}
+	if guidance.CodeBashingURL != "" {
+		markdown.WriteString(fmt.Sprintf("\n\n[CodeBashing lesson](%s)", guidance.CodeBashingURL))
+	}
+	return markdown.String()
+}
+
 func findProperties(result *wrappers.ScanResult) wrappers.SarifProperties {
 	var sarifProperties wrappers.SarifProperties
 	sarifProperties.ID = findRuleID(result)
@@ -3,12 +3,22 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/spf13/viper"
 	"gotest.tools/assert"
 )
 
@@ -54,6 +64,13 @@ func TestRunGetResultsByScanIdJsonFormat(t *testing.T) {
 	os.Remove(fmt.Sprintf("%s.%s", fileName, printer.FormatJSON))
 }
 
+func TestRunGetResultsByScanIdAllPages(t *testing.T) {
+	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "json", "--all")
+
+	// Remove generated json file
+	os.Remove(fmt.Sprintf("%s.%s", fileName, printer.FormatJSON))
+}
+
 func TestRunGetResultsByScanIdSummaryJsonFormat(t *testing.T) {
 	execCmdNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--report-format", "summaryJSON")
 
@@ -224,3 +241,507 @@ func TestRunGetBFLByScanIdAndQueryIdWithFormatList(t *testing.T) {
 	err := executeTestCommand(cmd, "results", "bfl", "--scan-id", "MOCK", "--query-id", "MOCK", "--format", "List")
 	assert.NilError(t, err)
 }
+
+// reportCheck is a report written by results show, with the content it must and must not contain
+type reportCheck struct {
+	file     string
+	contains []string
+	absent   []string
+	counts   map[string]int
+}
+
+func TestRunGetResultsReports(t *testing.T) {
+	const codeBashingLesson = "http://example.com/courses/php/lessons/dom_xss"
+	tests := []struct {
+		name    string
+		args    []string
+		sources map[string]string
+		reports []reportCheck
+	}{
+		{
+			name: "with triage history",
+			args: []string{"--report-format", "json", "--include-triage-history"},
+			reports: []reportCheck{
+				{
+					file:     fileName + ".json",
+					contains: []string{`"triageHistory":[{"state":"CONFIRMED","severity":"HIGH","comment":"MOCK","createdBy":"MOCK",`},
+					counts:   map[string]int{`"triageHistory"`: 2},
+				},
+			},
+		},
+		{
+			name:    "without triage history",
+			args:    []string{"--report-format", "json"},
+			reports: []reportCheck{{file: fileName + ".json", absent: []string{`"triageHistory"`}}},
+		},
+		{
+			name: "with guidance",
+			args: []string{"--report-format", "json,sarif", "--enrich"},
+			reports: []reportCheck{
+				{
+					file: fileName + ".json",
+					contains: []string{
+						`"guidance":{"risk":"MOCK","cause":"MOCK","generalRecommendations":"MOCK",`,
+						`"codeBashingUrl":"` + codeBashingLesson + `"`,
+					},
+				},
+				{
+					file:     fileName + ".sarif",
+					contains: []string{"Risk:", "[CodeBashing lesson](" + codeBashingLesson + ")"},
+				},
+			},
+		},
+		{
+			name:    "without guidance",
+			args:    []string{"--report-format", "json,sarif"},
+			reports: []reportCheck{{file: fileName + ".json", absent: []string{`"guidance"`}}, {file: fileName + ".sarif", absent: []string{"Risk:"}}},
+		},
+		{
+			name: "grouped by best fix location",
+			args: []string{"--report-format", "json", "--group-by", "bfl"},
+			reports: []reportCheck{
+				{
+					file:     fileName + ".json",
+					contains: []string{`"bestFixLocation":{"name":"MOCK","column":0,"domType":"MOCK","fileName":"MOCK","fullName":"MOCK"}`},
+					counts:   map[string]int{`"bestFixLocation"`: 1},
+				},
+			},
+		},
+		{
+			name:    "not grouped",
+			args:    []string{"--report-format", "json"},
+			reports: []reportCheck{{file: fileName + ".json", absent: []string{`"bestFixLocation"`}}},
+		},
+		{
+			name:    "with source snippets",
+			args:    []string{"--report-format", "json,markdown", "--snippet-context", "1"},
+			sources: map[string]string{"dummy-file-name": strings.Repeat("// code\n", 9) + "echo $_GET['name'];\n"},
+			reports: []reportCheck{
+				{
+					file: fileName + ".json",
+					contains: []string{
+						`"snippet":{"fileName":"dummy-file-name","line":10,"startLine":9,"lines":["// code","echo $_GET['name'];"]}`,
+					},
+				},
+				{
+					file:     fileName + ".md",
+					contains: []string{"## 1. HIGH sast: Reflected XSS All Clients", "   9 | // code\n> 10 | echo $_GET['name'];\n```"},
+				},
+			},
+		},
+		{
+			name:    "without source snippets",
+			args:    []string{"--report-format", "json,markdown"},
+			reports: []reportCheck{{file: fileName + ".json", absent: []string{`"snippet"`}}, {file: fileName + ".md", absent: []string{"```"}}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				viper.Set(params.DescriptionsCacheDirKey, t.TempDir())
+				defer viper.Set(params.DescriptionsCacheDirKey, "")
+				outputPath := t.TempDir()
+				args := append([]string{"results", "show", "--scan-id", "MOCK", "--output-path", outputPath}, tt.args...)
+				if tt.sources != nil {
+					sourceDir := t.TempDir()
+					for name, content := range tt.sources {
+						assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, name), []byte(content), 0600))
+					}
+					args = append(args, "--source-dir", sourceDir)
+				}
+				execCmdNilAssertion(t, args...)
+
+				for _, check := range tt.reports {
+					report, err := os.ReadFile(filepath.Join(outputPath, check.file))
+					assert.NilError(t, err)
+					for _, expected := range check.contains {
+						assert.Assert(t, strings.Contains(string(report), expected), "%s should contain %s", check.file, expected)
+					}
+					for _, unexpected := range check.absent {
+						assert.Assert(t, !strings.Contains(string(report), unexpected), "%s should not contain %s", check.file, unexpected)
+					}
+					for expected, count := range check.counts {
+						assert.Equal(t, strings.Count(string(report), expected), count, "%s should contain %s %d times", check.file, expected, count)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestWriteHTMLSummarySections(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  *wrappers.ResultSummary
+		contains []string
+	}{
+		{
+			name: "triage history",
+			summary: &wrappers.ResultSummary{
+				TriagedResults: []*wrappers.TriagedResultSummary{
+					{Type: "sast", Name: "SQL_Injection", State: "NOT_EXPLOITABLE", Comment: "<script>", Changes: 2},
+				},
+			},
+			contains: []string{"Triage History", "SQL_Injection", "NOT_EXPLOITABLE", "&lt;script&gt;"},
+		},
+		{
+			name: "guidance",
+			summary: &wrappers.ResultSummary{
+				Guidance: []*wrappers.GuidanceSummary{
+					{
+						QueryName:      "Reflected XSS All Clients",
+						Results:        3,
+						Risk:           "<script> injection",
+						CodeBashingURL: "http://example.com/courses/php/lessons/dom_xss",
+					},
+				},
+			},
+			contains: []string{
+				"Remediation Guidance",
+				"Reflected XSS All Clients",
+				"&lt;script&gt; injection",
+				`href="http://example.com/courses/php/lessons/dom_xss"`,
+			},
+		},
+		{
+			name: "best fix locations",
+			summary: &wrappers.ResultSummary{
+				BestFixLocations: []*wrappers.BestFixLocationSummary{
+					{FileName: "/src/login.php", Line: 12, Name: "$_GET", Queries: []string{"SQL Injection", "Reflected XSS"}, Results: 14},
+				},
+			},
+			contains: []string{"Best Fix Locations", "/src/login.php", "$_GET", "SQL Injection, Reflected XSS"},
+		},
+		{
+			name: "code snippets",
+			summary: &wrappers.ResultSummary{
+				Snippets: []*wrappers.SnippetSummary{
+					{Type: "sast", Name: "XSS", FileName: "index.php", Line: 3, Code: "> 3 | echo <b>;\n", Changed: true},
+				},
+			},
+			contains: []string{"Code Snippets", "index.php:3 (changed since the scan)", "<pre>&gt; 3 | echo &lt;b&gt;;\n</pre>"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				targetFile := filepath.Join(t.TempDir(), "summary.html")
+				tt.summary.Status = "Completed"
+				err := writeHTMLSummary(targetFile, tt.summary)
+				assert.NilError(t, err)
+
+				htmlReport, err := os.ReadFile(targetFile)
+				assert.NilError(t, err)
+				for _, expected := range tt.contains {
+					assert.Assert(t, strings.Contains(string(htmlReport), expected), "the summary should contain %s", expected)
+				}
+			},
+		)
+	}
+}
+
+func TestGuidanceEnricherLooksUpEachQueryOnce(t *testing.T) {
+	requests := 0
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{Type: params.SastType, ScanResultData: wrappers.ScanResultData{QueryID: json.Number("1"), QueryName: "SQL_Injection"}},
+			{Type: params.SastType, ScanResultData: wrappers.ScanResultData{QueryID: json.Number("1"), QueryName: "SQL_Injection"}},
+			{Type: params.SastType, ScanResultData: wrappers.ScanResultData{QueryID: json.Number("2"), QueryName: "Reflected_XSS"}},
+			{Type: params.KicsType, ScanResultData: wrappers.ScanResultData{QueryID: json.Number("1")}},
+		},
+	}
+	summary := &wrappers.ResultSummary{}
+	enrich := newGuidanceEnricher(&mock.LearnMoreMockWrapper{Requests: &requests}, &mock.CodeBashingMockWrapper{})
+
+	err := enrich(results, summary)
+	assert.NilError(t, err)
+	assert.Equal(t, requests, 1)
+	assert.Assert(t, results.Results[0].Guidance != nil)
+	assert.Equal(t, results.Results[0].Guidance, results.Results[1].Guidance)
+	assert.Assert(t, results.Results[2].Guidance != nil)
+	assert.Assert(t, results.Results[3].Guidance == nil)
+	assert.Equal(t, len(summary.Guidance), 2)
+	assert.Equal(t, summary.Guidance[0].QueryName, "SQL Injection")
+	assert.Equal(t, summary.Guidance[0].Results, 2)
+}
+
+// serveResults returns the HTTP results wrapper, reading payload from a test server
+func serveResults(t *testing.T, payload string) wrappers.ResultsWrapper {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/token") {
+					_, _ = fmt.Fprint(w, `{"access_token":"MOCK","expires_in":300}`)
+					return
+				}
+				_, _ = fmt.Fprint(w, payload)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	settings := map[string]string{
+		params.BaseURIKey:                     server.URL,
+		params.AstAuthenticationPathConfigKey: "auth/token",
+		params.AccessKeyIDConfigKey:           "MOCK",
+		params.AccessKeySecretConfigKey:       "MOCK",
+		params.AstToken:                       "",
+	}
+	for key, value := range settings {
+		key, previous := key, viper.GetString(key)
+		viper.Set(key, value)
+		t.Cleanup(func() { viper.Set(key, previous) })
+	}
+	return wrappers.NewHTTPResultsWrapper("api/results", "")
+}
+
+func TestGuidanceEnricherWithDecodedQueryIDs(t *testing.T) {
+	resultsWrapper := serveResults(
+		t,
+		`{"totalCount":2,"results":[
+			{"type":"sast","data":{"queryId":5157925289005576664,"queryName":"SQL_Injection"}},
+			{"type":"sast","data":{"queryId":5157925289005576664,"queryName":"SQL_Injection"}}
+		]}`,
+	)
+	results, webError, err := resultsWrapper.GetAllResultsByScanID(map[string]string{params.ScanIDQueryParam: "MOCK"})
+	assert.NilError(t, err)
+	assert.Assert(t, webError == nil)
+	summary := &wrappers.ResultSummary{}
+
+	err = newGuidanceEnricher(&mock.LearnMoreMockWrapper{}, &mock.CodeBashingMockWrapper{})(results, summary)
+	assert.NilError(t, err)
+	assert.Equal(t, wrappers.FormatQueryID(results.Results[0].ScanResultData.QueryID), "5157925289005576664")
+	assert.Assert(t, results.Results[0].Guidance != nil, "The description of 5157925289005576664 should be found")
+	assert.Equal(t, results.Results[0].Guidance, results.Results[1].Guidance)
+	assert.Equal(t, len(summary.Guidance), 1)
+	assert.Equal(t, summary.Guidance[0].Results, 2)
+}
+
+func TestRunGetResultsWithInvalidGroupBy(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "show", "--scan-id", "MOCK", "--group-by", "file")
+	assert.ErrorContains(t, err, "Invalid --group-by file")
+}
+
+func TestBflGroupEnricherMergesSharedLocations(t *testing.T) {
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{
+				Type:           params.SastType,
+				ScanResultData: wrappers.ScanResultData{QueryID: json.Number("1"), QueryName: "SQL_Injection", ResultHash: "MOCK-HASH"},
+			},
+			{Type: params.SastType, ScanResultData: wrappers.ScanResultData{QueryID: json.Number("2"), QueryName: "Reflected_XSS"}},
+			{Type: params.KicsType, ScanResultData: wrappers.ScanResultData{QueryID: 3}},
+		},
+	}
+	summary := &wrappers.ResultSummary{ScanID: "MOCK"}
+
+	err := newBflGroupEnricher(&mock.BflMockWrapper{})(results, summary)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summary.BestFixLocations), 1)
+	assert.DeepEqual(t, summary.BestFixLocations[0].Queries, []string{"SQL Injection", "Reflected XSS"})
+	assert.Equal(t, summary.BestFixLocations[0].Results, 2)
+	assert.Assert(t, results.Results[0].BestFixLocation != nil)
+	assert.Assert(t, results.Results[1].BestFixLocation == nil)
+}
+
+func TestWriteConsoleSummaryWithBestFixLocations(t *testing.T) {
+	summary := &wrappers.ResultSummary{
+		Status: "Completed",
+		BestFixLocations: []*wrappers.BestFixLocationSummary{
+			{FileName: "/src/login.php", Line: 12, Name: "$_GET", Queries: []string{"SQL Injection", "Reflected XSS"}, Results: 14},
+		},
+	}
+	var err error
+	output := captureStdout(
+		t, func() {
+			err = writeConsoleSummary(summary)
+		},
+	)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(output, "Best Fix Locations:"))
+	assert.Assert(t, strings.Contains(output, "/src/login.php"))
+	assert.Assert(t, strings.Contains(output, "$_GET"))
+	assert.Assert(t, strings.Contains(output, "SQL Injection, Reflected XSS"))
+	assert.Assert(t, strings.Contains(output, "14"))
+}
+
+// captureStdout returns what print writes to the standard output
+func captureStdout(t *testing.T, print func()) string {
+	reader, writer, err := os.Pipe()
+	assert.NilError(t, err)
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() {
+		os.Stdout = stdout
+	}()
+	print()
+	assert.NilError(t, writer.Close())
+	output, err := io.ReadAll(reader)
+	assert.NilError(t, err)
+	return string(output)
+}
+
+func TestRunGetResultTraceByResultID(t *testing.T) {
+	outputPath := t.TempDir()
+	execCmdNilAssertion(
+		t,
+		"results", "trace",
+		"--scan-id", "MOCK",
+		"--result-id", "MOCK-SAST-ID",
+		"--output-path", outputPath,
+	)
+
+	trace, err := os.ReadFile(filepath.Join(outputPath, "MOCK-SAST-ID.dot"))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(trace), `digraph "MOCK-SAST-ID" {`))
+	assert.Assert(t, strings.Contains(string(trace), `n0 [label="dummy-file-name:10"];`))
+	assert.Assert(t, strings.Contains(string(trace), "n0 -> n1;"))
+}
+
+func TestRunGetResultTraceBflBatch(t *testing.T) {
+	outputPath := t.TempDir()
+	execCmdNilAssertion(
+		t,
+		"results", "trace",
+		"--scan-id", "MOCK",
+		"--graph", "bfl",
+		"--format", "json",
+		"--output-path", outputPath,
+	)
+
+	trace, err := os.ReadFile(filepath.Join(outputPath, "MOCK-SAST-ID.json"))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(trace), `"graph":"bfl"`))
+	assert.Assert(t, strings.Contains(string(trace), `"edges":[{"from":"n0","to":"n1"}]`))
+	assert.Assert(t, strings.Contains(string(trace), `"bestFix":true`))
+}
+
+func TestRunGetResultTraceBflRequestsEachQueryOnce(t *testing.T) {
+	resultsWrapper := serveResults(
+		t,
+		`{"totalCount":3,"results":[
+			{"type":"sast","id":"a/b+c=","data":{"queryId":5157925289005576664,"resultHash":"MOCK-HASH"}},
+			{"type":"sast","id":"d/e+f=","data":{"queryId":5157925289005576664,"resultHash":"MOCK-HASH"}},
+			{"type":"sast","id":"g/h+i=","data":{"queryId":5157925289005576664,"resultHash":"MOCK-HASH"}}
+		]}`,
+	)
+	var requests int32
+	outputPath := t.TempDir()
+	cmd := resultTraceSubCommand(resultsWrapper, &mock.BflMockWrapper{Requests: &requests})
+	cmd.SetArgs([]string{"--scan-id", "MOCK", "--graph", "bfl", "--format", "json", "--output-path", outputPath})
+	assert.NilError(t, cmd.Execute())
+
+	assert.Equal(t, requests, int32(1), "The trees of a query should be requested once")
+	for _, name := range []string{"a%2Fb+c=.json", "d%2Fe+f=.json", "g%2Fh+i=.json"} {
+		trace, err := os.ReadFile(filepath.Join(outputPath, name))
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(string(trace), `"bestFix":true`))
+	}
+}
+
+func TestRunGetResultTraceWithUnknownResult(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "results", "trace", "--scan-id", "MOCK", "--result-id", "MISSING")
+	assert.ErrorContains(t, err, "Result MISSING not found in scan MOCK")
+	err = execCmdNotNilAssertion(t, "results", "trace", "--scan-id", "MOCK", "--format", "svg")
+	assert.ErrorContains(t, err, "Invalid format svg")
+}
+
+func TestWriteTraceGraph(t *testing.T) {
+	graph := &traceGraph{
+		ResultID: "1",
+		Query:    "SQL Injection",
+		Nodes: []*traceNode{
+			{ID: "n0", Label: "login.php:3\nlogin\n\"user\""},
+			{ID: "n1", Label: "login.php:9\nlogin\nquery", BestFix: true},
+		},
+		Edges: []*traceEdge{{From: "n0", To: "n1"}},
+	}
+	dot := &bytes.Buffer{}
+	writeTraceGraph(dot, traceFormatDOT, graph)
+	assert.Equal(
+		t, dot.String(), `digraph "1" {
+  label="SQL Injection";
+  node [shape=box];
+  n0 [label="login.php:3\nlogin\n\"user\""];
+  n1 [label="login.php:9\nlogin\nquery", color=red, penwidth=2];
+  n0 -> n1;
+}
+`,
+	)
+	mermaid := &bytes.Buffer{}
+	writeTraceGraph(mermaid, traceFormatMermaid, graph)
+	assert.Equal(
+		t, mermaid.String(), `flowchart TD
+  n0["login.php:3<br/>login<br/>#quot;user#quot;"]
+  n1["login.php:9<br/>login<br/>query"]
+  n0 --> n1
+  style n1 stroke:#d00,stroke-width:3px
+`,
+	)
+}
+
+func TestSnippetEnricher(t *testing.T) {
+	sourceDir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(sourceDir, "src"), 0700))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "src", "login.php"), []byte("<?php\n$user = $_GET['user'];\nquery($user);\n"), 0600))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "Dockerfile"), []byte("FROM alpine\nUSER root\n"), 0600))
+	assert.NilError(t, os.WriteFile(filepath.Join(sourceDir, "package.json"), []byte("{\n  \"lodash\": \"4.17.15\"\n}\n"), 0600))
+	manifest := "package.json"
+	results := &wrappers.ScanResultsCollection{
+		Results: []*wrappers.ScanResult{
+			{
+				Type: params.SastType,
+				ScanResultData: wrappers.ScanResultData{
+					QueryName: "SQL_Injection",
+					Nodes: []*wrappers.ScanResultNode{
+						{FileName: "/src/login.php", Line: 2, Name: "$_GET"},
+						{FileName: "/src/login.php", Line: 3, Name: "execute"},
+						{FileName: "/src/missing.php", Line: 1, Name: "query"},
+					},
+				},
+			},
+			{Type: params.KicsType, ScanResultData: wrappers.ScanResultData{Filename: "Dockerfile", Line: 2}},
+			{
+				Type: params.ScaType,
+				ScanResultData: wrappers.ScanResultData{
+					ScaPackageCollection: &wrappers.ScaPackageCollection{
+						Locations:           []*string{&manifest},
+						DependencyPathArray: [][]wrappers.DependencyPath{{{Name: "lodash"}}},
+					},
+				},
+			},
+		},
+	}
+	summary := &wrappers.ResultSummary{}
+
+	err := newSnippetEnricher(sourceDir, 1)(results, summary)
+	assert.NilError(t, err)
+	nodes := results.Results[0].ScanResultData.Nodes
+	assert.DeepEqual(t, nodes[0].Snippet.Lines, []string{"<?php", "$user = $_GET['user'];", "query($user);"})
+	assert.Equal(t, nodes[0].Snippet.StartLine, uint(1))
+	assert.Assert(t, !nodes[0].Snippet.Changed)
+	assert.Assert(t, nodes[1].Snippet.Changed)
+	assert.Assert(t, nodes[2].Snippet == nil)
+	assert.DeepEqual(t, results.Results[1].ScanResultData.Snippet.Lines, []string{"FROM alpine", "USER root"})
+	assert.Equal(t, results.Results[2].ScanResultData.ScaPackageCollection.Snippets[0].Line, uint(2))
+	assert.Equal(t, len(summary.Snippets), 3)
+	assert.Equal(t, summary.Snippets[0].Code, "  1 | <?php\n> 2 | $user = $_GET['user'];\n  3 | query($user);\n")
+}
+
+func TestRunGetResultsWithMissingSourceDir(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t, "results", "show", "--scan-id", "MOCK", "--output-path", t.TempDir(), "--source-dir", "/nonexistent/sources",
+	)
+	assert.ErrorContains(t, err, "/nonexistent/sources is not a directory")
+}
+
+func TestExportMarkdownResultsWriteError(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full is not available")
+	}
+	results := &wrappers.ScanResultsCollection{Results: []*wrappers.ScanResult{{Type: params.KicsType, Severity: "low"}}}
+
+	err := exportMarkdownResults("/dev/full", results, &wrappers.ResultSummary{})
+	assert.ErrorContains(t, err, "failed to write target file")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
@@ -37,6 +38,9 @@ func NewAstCLI(
 	gitLabWrapper wrappers.GitLabWrapper,
 	bflWrapper wrappers.BflWrapper,
 	learnMoreWrapper wrappers.LearnMoreWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+	healthCheckWrapper wrappers.HealthCheckWrapper,
+	diagnosticsWrapper wrappers.DiagnosticsWrapper,
 ) *cobra.Command {
 	// Create the root
 	rootCmd := &cobra.Command{
@@ -69,9 +73,19 @@ func NewAstCLI(
 	rootCmd.PersistentFlags().String(params.AccessKeyIDFlag, "", params.AccessKeyIDFlagUsage)
 	rootCmd.PersistentFlags().String(params.AccessKeySecretFlag, "", params.AccessKeySecretFlagUsage)
 	rootCmd.PersistentFlags().Bool(params.InsecureFlag, false, params.InsecureFlagUsage)
+	rootCmd.PersistentFlags().String(params.CACertFlag, "", params.CACertFlagUsage)
+	rootCmd.PersistentFlags().String(params.ClientCertFlag, "", params.ClientCertFlagUsage)
+	rootCmd.PersistentFlags().String(params.ClientKeyFlag, "", params.ClientKeyFlagUsage)
+	rootCmd.PersistentFlags().String(params.MinTLSVersionFlag, "", params.MinTLSVersionFlagUsage)
 	rootCmd.PersistentFlags().String(params.ProxyFlag, "", params.ProxyFlagUsage)
+	rootCmd.PersistentFlags().String(params.NoProxyFlag, "", params.NoProxyFlagUsage)
+	rootCmd.PersistentFlags().String(params.ProxyRulesFlag, "", params.ProxyRulesFlagUsage)
 	rootCmd.PersistentFlags().String(params.ProxyTypeFlag, "", params.ProxyTypeFlagUsage)
 	rootCmd.PersistentFlags().String(params.NtlmProxyDomainFlag, "", params.NtlmProxyDomainFlagUsage)
+	rootCmd.PersistentFlags().String(params.KrbProxyConfFlag, "", params.KrbProxyConfFlagUsage)
+	rootCmd.PersistentFlags().String(params.KrbProxyKeytabFlag, "", params.KrbProxyKeytabFlagUsage)
+	rootCmd.PersistentFlags().String(params.KrbProxyPrincipalFlag, "", params.KrbProxyPrincipalFlagUsage)
+	rootCmd.PersistentFlags().String(params.KrbProxyCCacheFlag, "", params.KrbProxyCCacheFlagUsage)
 	rootCmd.PersistentFlags().String(params.TimeoutFlag, "", params.TimeoutFlagUsage)
 	rootCmd.PersistentFlags().String(params.BaseURIFlag, params.BaseURI, params.BaseURIFlagUsage)
 	rootCmd.PersistentFlags().String(params.BaseAuthURIFlag, params.BaseIAMURI, params.BaseAuthURIFlagUsage)
@@ -99,12 +113,22 @@ func NewAstCLI(
 	_ = viper.BindPFlag(params.BaseURIKey, rootCmd.PersistentFlags().Lookup(params.BaseURIFlag))
 	_ = viper.BindPFlag(params.TenantKey, rootCmd.PersistentFlags().Lookup(params.TenantFlag))
 	_ = viper.BindPFlag(params.ProxyKey, rootCmd.PersistentFlags().Lookup(params.ProxyFlag))
+	_ = viper.BindPFlag(params.NoProxyKey, rootCmd.PersistentFlags().Lookup(params.NoProxyFlag))
+	_ = viper.BindPFlag(params.ProxyRulesKey, rootCmd.PersistentFlags().Lookup(params.ProxyRulesFlag))
 	_ = viper.BindPFlag(params.ProxyTypeKey, rootCmd.PersistentFlags().Lookup(params.ProxyTypeFlag))
 	_ = viper.BindPFlag(params.ProxyDomainKey, rootCmd.PersistentFlags().Lookup(params.NtlmProxyDomainFlag))
+	_ = viper.BindPFlag(params.ProxyKrb5ConfKey, rootCmd.PersistentFlags().Lookup(params.KrbProxyConfFlag))
+	_ = viper.BindPFlag(params.ProxyKeytabKey, rootCmd.PersistentFlags().Lookup(params.KrbProxyKeytabFlag))
+	_ = viper.BindPFlag(params.ProxyPrincipalKey, rootCmd.PersistentFlags().Lookup(params.KrbProxyPrincipalFlag))
+	_ = viper.BindPFlag(params.ProxyCCacheKey, rootCmd.PersistentFlags().Lookup(params.KrbProxyCCacheFlag))
 	_ = viper.BindPFlag(params.ClientTimeoutKey, rootCmd.PersistentFlags().Lookup(params.TimeoutFlag))
 	_ = viper.BindPFlag(params.BaseAuthURIKey, rootCmd.PersistentFlags().Lookup(params.BaseAuthURIFlag))
 	_ = viper.BindPFlag(params.AstAPIKey, rootCmd.PersistentFlags().Lookup(params.AstAPIKeyFlag))
 	_ = viper.BindPFlag(params.AgentNameKey, rootCmd.PersistentFlags().Lookup(params.AgentFlag))
+	_ = viper.BindPFlag(params.CACertKey, rootCmd.PersistentFlags().Lookup(params.CACertFlag))
+	_ = viper.BindPFlag(params.ClientCertKey, rootCmd.PersistentFlags().Lookup(params.ClientCertFlag))
+	_ = viper.BindPFlag(params.ClientKeyKey, rootCmd.PersistentFlags().Lookup(params.ClientKeyFlag))
+	_ = viper.BindPFlag(params.MinTLSVersionKey, rootCmd.PersistentFlags().Lookup(params.MinTLSVersionFlag))
 	// Key here is the actual flag since it doesn't use an environment variable
 	_ = viper.BindPFlag(params.DebugFlag, rootCmd.PersistentFlags().Lookup(params.DebugFlag))
 	_ = viper.BindPFlag(params.InsecureFlag, rootCmd.PersistentFlags().Lookup(params.InsecureFlag))
@@ -119,14 +143,49 @@ func NewAstCLI(
 	)
 
 	// Create the CLI command structure
-	scanCmd := NewScanCommand(scansWrapper, uploadsWrapper, resultsWrapper, projectsWrapper, logsWrapper, groupsWrapper)
-	projectCmd := NewProjectCommand(projectsWrapper, groupsWrapper)
-	resultsCmd := NewResultsCommand(resultsWrapper, scansWrapper, codeBashingWrapper, bflWrapper)
+	scanCmd := NewScanCommand(
+		scansWrapper,
+		uploadsWrapper,
+		resultsWrapper,
+		projectsWrapper,
+		logsWrapper,
+		groupsWrapper,
+		hooksWrapper,
+		learnMoreWrapper,
+		codeBashingWrapper,
+	)
+	projectCmd := NewProjectCommand(
+		projectsWrapper,
+		groupsWrapper,
+		scansWrapper,
+		resultsWrapper,
+		gitHubWrapper,
+		azureWrapper,
+		bitBucketWrapper,
+		gitLabWrapper,
+	)
+	resultsCmd := NewResultsCommand(
+		resultsWrapper,
+		scansWrapper,
+		codeBashingWrapper,
+		bflWrapper,
+		resultsPredicatesWrapper,
+		learnMoreWrapper,
+	)
 	versionCmd := util.NewVersionCommand()
 	authCmd := NewAuthCommand(authWrapper)
-	utilsCmd := util.NewUtilsCommand(gitHubWrapper, azureWrapper, bitBucketWrapper, gitLabWrapper, learnMoreWrapper)
+	utilsCmd := util.NewUtilsCommand(
+		gitHubWrapper,
+		azureWrapper,
+		bitBucketWrapper,
+		gitLabWrapper,
+		learnMoreWrapper,
+		resultsWrapper,
+		healthCheckWrapper,
+	)
 	configCmd := util.NewConfigCommand()
-	triageCmd := NewResultsPredicatesCommand(resultsPredicatesWrapper)
+	triageCmd := NewResultsPredicatesCommand(resultsPredicatesWrapper, resultsWrapper, scansWrapper)
+	doctorCmd := NewDoctorCommand(diagnosticsWrapper, projectsWrapper)
 
 	rootCmd.AddCommand(
 		scanCmd,
@@ -137,6 +196,7 @@ func NewAstCLI(
 		authCmd,
 		utilsCmd,
 		configCmd,
+		doctorCmd,
 	)
 
 	rootCmd.SilenceErrors = true
@@ -173,6 +233,41 @@ func getFilters(cmd *cobra.Command) (map[string]string, error) {
 	return allFilters, nil
 }
 
+func addAllPagesFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(params.AllPagesFlag, false, params.AllPagesFlagUsage)
+	cmd.PersistentFlags().Int(params.ConcurrencyFlag, params.ConcurrencyDefault, params.ConcurrencyFlagUsage)
+}
+
+// getAllPagesFlags returns whether every page was requested, with the page size taken from the limit filter
+func getAllPagesFlags(cmd *cobra.Command, filters map[string]string) (all bool, pageSize, concurrency int, err error) {
+	all, _ = cmd.Flags().GetBool(params.AllPagesFlag)
+	if !all {
+		return false, 0, 0, nil
+	}
+	if _, found := filters[params.OffsetQueryParam]; found {
+		return false, 0, 0, errors.Errorf("The %s filter can't be used with --%s", params.OffsetQueryParam, params.AllPagesFlag)
+	}
+	if limit, found := filters[params.LimitQueryParam]; found {
+		pageSize, err = strconv.Atoi(limit)
+		if err != nil || pageSize < 1 {
+			return false, 0, 0, errors.Errorf("Invalid %s filter %s", params.LimitQueryParam, limit)
+		}
+	}
+	concurrency, _ = cmd.Flags().GetInt(params.ConcurrencyFlag)
+	if concurrency < 1 {
+		return false, 0, 0, errors.Errorf("--%s should be higher than 0", params.ConcurrencyFlag)
+	}
+	return all, pageSize, concurrency, nil
+}
+
+// warnTruncated warns when the listing stopped before the total count of items
+func warnTruncated(kind string, filters map[string]string, count int, total uint) {
+	offset, _ := strconv.Atoi(filters[params.OffsetQueryParam])
+	if uint(offset+count) < total {
+		logger.Printf("Warning: showing %d of %d %s, use --%s to get all of them", count, total, kind, params.AllPagesFlag)
+	}
+}
+
 func addFormatFlagToMultipleCommands(commands []*cobra.Command, defaultFormat string, otherAvailableFormats ...string) {
 	for _, c := range commands {
 		addFormatFlag(c, defaultFormat, otherAvailableFormats...)
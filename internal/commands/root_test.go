@@ -1,11 +1,14 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"github.com/spf13/viper"
 
@@ -28,8 +31,11 @@ func TestMain(m *testing.M) {
 }
 
 func createASTTestCommand() *cobra.Command {
-	scansMockWrapper := &mock.ScansMockWrapper{}
-	scansMockWrapper.Running = true
+	return createASTTestCommandWithMocks(&mock.ScansMockWrapper{Running: true}, &mock.HooksMockWrapper{})
+}
+
+// createASTTestCommandWithMocks lets the test set up and inspect the scans and hooks mocks
+func createASTTestCommandWithMocks(scansMockWrapper wrappers.ScansWrapper, hooksMockWrapper *mock.HooksMockWrapper) *cobra.Command {
 	resultsPredicatesMockWrapper := &mock.ResultsPredicatesMockWrapper{}
 	groupsMockWrapper := &mock.GroupsMockWrapper{}
 	uploadsMockWrapper := &mock.UploadsMockWrapper{}
@@ -44,6 +50,8 @@ func createASTTestCommand() *cobra.Command {
 	gitLabWrapper := &mock.GitLabMockWrapper{}
 	bflMockWrapper := &mock.BflMockWrapper{}
 	learnMoreMockWrapper := &mock.LearnMoreMockWrapper{}
+	healthCheckMockWrapper := &mock.HealthCheckMockWrapper{}
+	diagnosticsMockWrapper := &mock.DiagnosticsMockWrapper{}
 
 	return NewAstCLI(
 		scansMockWrapper,
@@ -61,6 +69,9 @@ func createASTTestCommand() *cobra.Command {
 		gitLabWrapper,
 		bflMockWrapper,
 		learnMoreMockWrapper,
+		hooksMockWrapper,
+		healthCheckMockWrapper,
+		diagnosticsMockWrapper,
 	)
 }
 
@@ -94,3 +105,14 @@ func execCmdNotNilAssertion(t *testing.T, args ...string) error {
 	assert.Assert(t, err != nil)
 	return err
 }
+
+func TestWarnTruncated(t *testing.T) {
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	warnTruncated("scans", map[string]string{"offset": "10"}, 20, 30)
+	assert.Equal(t, output.Len(), 0)
+	warnTruncated("scans", map[string]string{}, 20, 30)
+	assert.Assert(t, strings.Contains(output.String(), "Warning: showing 20 of 30 scans, use --all to get all of them"), output.String())
+}
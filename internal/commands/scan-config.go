@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const failedLoadingScanConfig = "Failed loading the scan configuration file"
+
+type scanConfigFlag struct {
+	flag  string
+	value string
+}
+
+// applyScanConfigFile looks for a project configuration file in the source directory and uses it to fill the
+// flags that were not provided. Explicit flags win over environment variables, which win over the file.
+func applyScanConfigFile(cmd *cobra.Command) error {
+	source, _ := cmd.Flags().GetString(commonParams.SourcesFlag)
+	configPath := configuration.FindScanConfigFile(strings.TrimSpace(source))
+	if configPath == "" {
+		return nil
+	}
+	scanConfig, err := configuration.LoadScanConfig(configPath)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedLoadingScanConfig)
+	}
+	logger.PrintIfVerbose(fmt.Sprintf("Using scan configuration file %s", configPath))
+
+	// Branch is the only one of these values that can also come from the environment
+	if getScanBranch(cmd) == "" {
+		err = setFlagFromScanConfig(cmd, commonParams.BranchFlag, scanConfig.Scan.Branch)
+		if err != nil {
+			return err
+		}
+	}
+	flagValues := []scanConfigFlag{
+		{commonParams.ProjectName, scanConfig.Project.Name},
+		{commonParams.ProjectGroupList, strings.Join(scanConfig.Project.Groups, ",")},
+		{commonParams.ProjectTagList, strings.Join(scanConfig.Project.Tags, ",")},
+		{commonParams.ScanTypes, strings.Join(scanConfig.Scan.Types, ",")},
+		{commonParams.TagList, strings.Join(scanConfig.Scan.Tags, ",")},
+		{commonParams.SourceDirFilterFlag, scanConfig.Scan.FileFilter},
+		{commonParams.IncludeFilterFlag, scanConfig.Scan.FileInclude},
+		{commonParams.SastFilterFlag, scanConfig.Scan.Sast.Filter},
+		{commonParams.PresetName, scanConfig.Scan.Sast.PresetName},
+		{commonParams.KicsFilterFlag, scanConfig.Scan.Kics.Filter},
+		{commonParams.KicsPlatformsFlag, scanConfig.Scan.Kics.Platforms},
+		{commonParams.ScaFilterFlag, scanConfig.Scan.Sca.Filter},
+		{commonParams.Threshold, scanConfig.ThresholdString()},
+		{commonParams.TargetFormatFlag, strings.Join(scanConfig.Report.Formats, ",")},
+		{commonParams.TargetFlag, scanConfig.Report.OutputName},
+		{commonParams.TargetPathFlag, scanConfig.Report.OutputPath},
+	}
+	if scanConfig.Scan.Sast.Incremental != nil {
+		flagValues = append(
+			flagValues,
+			scanConfigFlag{commonParams.IncrementalSast, strconv.FormatBool(*scanConfig.Scan.Sast.Incremental)},
+		)
+	}
+	for _, flagValue := range flagValues {
+		err = setFlagFromScanConfig(cmd, flagValue.flag, flagValue.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setFlagFromScanConfig(cmd *cobra.Command, flagName, value string) error {
+	if value == "" || cmd.Flags().Changed(flagName) {
+		return nil
+	}
+	err := cmd.Flags().Set(flagName, value)
+	if err != nil {
+		return errors.Wrapf(err, "%s: invalid value for %s", failedLoadingScanConfig, flagName)
+	}
+	logger.PrintIfVerbose(fmt.Sprintf("Setting --%s from the scan configuration file", flagName))
+	return nil
+}
@@ -0,0 +1,413 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	failedManifestScan     = "Failed scanning the manifest projects"
+	manifestSummaryName    = "%s_manifest"
+	manifestFailedStatus   = "Failed"
+	manifestSeverityHigh   = "high"
+	manifestSeverityMedium = "medium"
+	manifestSeverityLow    = "low"
+)
+
+var (
+	// Flags that only make sense for the whole manifest and are never forwarded to the project scans
+	manifestOnlyFlags = map[string]bool{
+		commonParams.ManifestFlag:            true,
+		commonParams.ManifestParallelismFlag: true,
+		commonParams.SourcesFlag:             true,
+		commonParams.ProjectName:             true,
+	}
+	projectNameFileReplacer = strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_")
+)
+
+type manifestScanView struct {
+	ProjectName string `format:"name:Project name"`
+	Source      string
+	ScanID      string `format:"name:Scan ID"`
+	Status      string
+	High        int
+	Medium      int
+	Low         int
+	Error       string `json:"Error,omitempty"`
+}
+
+func runManifestScans(
+	cmd *cobra.Command,
+	manifestPath string,
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+) error {
+	manifest, err := configuration.LoadScanManifest(manifestPath)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedManifestScan)
+	}
+	parallelism := manifest.Parallelism
+	if parallelism == 0 || cmd.Flags().Changed(commonParams.ManifestParallelismFlag) {
+		parallelism, _ = cmd.Flags().GetInt(commonParams.ManifestParallelismFlag)
+	}
+	if parallelism < 1 {
+		return errors.Errorf("--%s should be higher than 0", commonParams.ManifestParallelismFlag)
+	}
+	// Every project builds its own hooks, this only reports invalid hook flags before any scan is created
+	_, err = newScanHooks(cmd, hooksWrapper, scansWrapper, resultsWrapper)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedManifestScan)
+	}
+	log.Printf("Scanning %d projects from %s, %d at a time\n", len(manifest.Services), manifestPath, parallelism)
+
+	views := make([]*manifestScanView, len(manifest.Services))
+	// Building a project command binds the viper branch to it, so the commands are built before the workers start
+	serviceCmds := make([]*cobra.Command, len(manifest.Services))
+	for i := range manifest.Services {
+		serviceCmds[i], err = newManifestServiceCommand(
+			cmd,
+			&manifest.Services[i],
+			scansWrapper,
+			uploadsWrapper,
+			resultsWrapper,
+			projectsWrapper,
+			groupsWrapper,
+			hooksWrapper,
+		)
+		if err != nil {
+			views[i] = &manifestScanView{
+				ProjectName: manifest.Services[i].ProjectName,
+				Source:      manifest.Services[i].Path,
+				Status:      manifestFailedStatus,
+				Error:       err.Error(),
+			}
+		}
+	}
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, parallelism)
+	for i := range manifest.Services {
+		if views[i] != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			views[i] = runManifestService(
+				cmd,
+				serviceCmds[i],
+				&manifest.Services[i],
+				scansWrapper,
+				uploadsWrapper,
+				resultsWrapper,
+				projectsWrapper,
+				groupsWrapper,
+				hooksWrapper,
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	err = printManifestSummary(cmd, views)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, view := range views {
+		if view.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%s: %d of %d project scans failed", failedManifestScan, failed, len(views))
+	}
+	return nil
+}
+
+func runManifestService(
+	cmd, serviceCmd *cobra.Command,
+	service *configuration.ScanManifestService,
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+) *manifestScanView {
+	view := &manifestScanView{ProjectName: service.ProjectName, Source: service.Path}
+	scanResponseModel, err := createManifestServiceScan(
+		serviceCmd,
+		scansWrapper,
+		uploadsWrapper,
+		projectsWrapper,
+		groupsWrapper,
+	)
+	if err != nil {
+		view.Status = manifestFailedStatus
+		view.Error = err.Error()
+		return view
+	}
+	view.ScanID = scanResponseModel.ID
+	view.Status = string(scanResponseModel.Status)
+	hooks, err := newScanHooks(serviceCmd, hooksWrapper, scansWrapper, resultsWrapper)
+	if err != nil {
+		view.Error = err.Error()
+		return view
+	}
+	hooks.fire(wrappers.HookScanCreated, scanResponseModel, "")
+
+	asyncFlag, _ := cmd.Flags().GetBool(commonParams.AsyncFlag)
+	if asyncFlag {
+		return view
+	}
+	waitDelay, _ := serviceCmd.Flags().GetInt(commonParams.WaitDelayFlag)
+	timeoutMinutes, _ := serviceCmd.Flags().GetInt(commonParams.ScanTimeoutFlag)
+	err = handleWait(serviceCmd, scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper, hooks)
+	if err != nil {
+		view.Status = manifestFailedStatus
+		view.Error = err.Error()
+		return view
+	}
+	view.Status = string(wrappers.ScanCompleted)
+
+	err = createManifestServiceReports(serviceCmd, scanResponseModel.ID, scansWrapper, resultsWrapper)
+	if err != nil {
+		view.Error = err.Error()
+		return view
+	}
+	summaryMap, err := getSummaryThresholdMap(resultsWrapper, scanResponseModel.ID)
+	if err != nil {
+		view.Error = err.Error()
+		return view
+	}
+	for key, count := range summaryMap {
+		switch {
+		case strings.HasSuffix(key, "-"+manifestSeverityHigh):
+			view.High += count
+		case strings.HasSuffix(key, "-"+manifestSeverityMedium):
+			view.Medium += count
+		case strings.HasSuffix(key, "-"+manifestSeverityLow):
+			view.Low += count
+		}
+	}
+	err = applyThreshold(serviceCmd, resultsWrapper, scanResponseModel, hooks)
+	if err != nil {
+		view.Error = err.Error()
+	}
+	return view
+}
+
+func createManifestServiceScan(
+	serviceCmd *cobra.Command,
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+) (*wrappers.ScanResponseModel, error) {
+	zipFilePath := ""
+	defer func() {
+		cleanUpTempZip(zipFilePath)
+	}()
+	branch, _ := serviceCmd.Flags().GetString(commonParams.BranchFlag)
+	if branch == "" {
+		return nil, errors.Errorf("%s: Please provide a branch", failedCreating)
+	}
+	scanTypes, err := getScanTypes(serviceCmd)
+	if err != nil {
+		return nil, err
+	}
+	var scanModel *wrappers.Scan
+	scanModel, zipFilePath, err = createScanModel(serviceCmd, scanTypes, branch, uploadsWrapper, projectsWrapper, groupsWrapper)
+	if err != nil {
+		return nil, err
+	}
+	scanResponseModel, errorModel, err := scansWrapper.Create(scanModel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedCreating)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(ErrorCodeFormat, failedCreating, errorModel.Code, errorModel.Message)
+	}
+	scanResponseModel = enrichScanResponseModel(serviceCmd, scanResponseModel)
+	err = printByScanInfoFormat(serviceCmd, toScanView(scanResponseModel))
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s\n", failedCreating)
+	}
+	return scanResponseModel, nil
+}
+
+// newManifestServiceCommand builds a scan create command for a single manifest project. It inherits the flags
+// given to the manifest scan, then the manifest values and finally the project configuration file.
+func newManifestServiceCommand(
+	cmd *cobra.Command,
+	service *configuration.ScanManifestService,
+	scansWrapper wrappers.ScansWrapper,
+	uploadsWrapper wrappers.UploadsWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+) (*cobra.Command, error) {
+	serviceCmd := scanCreateSubCommand(
+		scansWrapper,
+		uploadsWrapper,
+		resultsWrapper,
+		projectsWrapper,
+		groupsWrapper,
+		hooksWrapper,
+		nil,
+		nil,
+	)
+	addScanInfoFormatFlag(serviceCmd, printer.FormatList, printer.FormatTable, printer.FormatJSON)
+	serviceCmd.SetOut(cmd.OutOrStdout())
+	// Parsing merges the persistent flags into the flag set used by the scan create flow
+	err := serviceCmd.ParseFlags(nil)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Flags().Visit(
+		func(flag *pflag.Flag) {
+			serviceFlag := serviceCmd.Flags().Lookup(flag.Name)
+			if err != nil || serviceFlag == nil || manifestOnlyFlags[flag.Name] {
+				return
+			}
+			err = copyFlagValue(serviceCmd, flag, serviceFlag)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	serviceValues := []scanConfigFlag{
+		{commonParams.SourcesFlag, service.Path},
+		{commonParams.ProjectName, service.ProjectName},
+		{commonParams.BranchFlag, service.Branch},
+		{commonParams.ScanTypes, strings.Join(service.ScanTypes, ",")},
+		{commonParams.TagList, strings.Join(service.Tags, ",")},
+		{commonParams.ProjectTagList, strings.Join(service.ProjectTags, ",")},
+		{commonParams.ProjectGroupList, strings.Join(service.ProjectGroups, ",")},
+	}
+	for _, serviceValue := range serviceValues {
+		if serviceValue.value == "" {
+			continue
+		}
+		err = serviceCmd.Flags().Set(serviceValue.flag, serviceValue.value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = applyScanConfigFile(serviceCmd)
+	if err != nil {
+		return nil, err
+	}
+	// The viper branch follows the last built command, so the value from the environment is kept in the project flag
+	branch := getScanBranch(serviceCmd)
+	if branch != "" && !serviceCmd.Flags().Changed(commonParams.BranchFlag) {
+		err = serviceCmd.Flags().Set(commonParams.BranchFlag, branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The projects are waited concurrently, a single live progress line cannot represent them
+	err = serviceCmd.Flags().Set(commonParams.NoProgressFlag, "true")
+	if err != nil {
+		return nil, err
+	}
+	targetFile, _ := serviceCmd.Flags().GetString(commonParams.TargetFlag)
+	err = serviceCmd.Flags().Set(
+		commonParams.TargetFlag,
+		fmt.Sprintf("%s_%s", targetFile, projectNameFileReplacer.Replace(service.ProjectName)),
+	)
+	return serviceCmd, err
+}
+
+func copyFlagValue(cmd *cobra.Command, from, to *pflag.Flag) error {
+	if fromSlice, ok := from.Value.(pflag.SliceValue); ok {
+		if toSlice, ok := to.Value.(pflag.SliceValue); ok {
+			to.Changed = true
+			return toSlice.Replace(fromSlice.GetSlice())
+		}
+	}
+	return cmd.Flags().Set(to.Name, from.Value.String())
+}
+
+// createManifestServiceReports writes the report files of a single project. The console summary is left out
+// because the projects finish concurrently, a combined summary is printed at the end instead.
+func createManifestServiceReports(
+	cmd *cobra.Command,
+	scanID string,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+) error {
+	targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+	targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+	reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+	var formats []string
+	for _, format := range strings.Split(reportFormats, ",") {
+		if strings.TrimSpace(format) != "" && !printer.IsFormat(format, printer.FormatSummaryConsole) {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return nil
+	}
+	params, err := getFilters(cmd)
+	if err != nil {
+		return err
+	}
+	return CreateScanReport(
+		resultsWrapper,
+		scansWrapper,
+		scanID,
+		strings.Join(formats, ","),
+		targetFile,
+		targetPath,
+		params,
+	)
+}
+
+func printManifestSummary(cmd *cobra.Command, views []*manifestScanView) error {
+	err := printByScanInfoFormat(cmd, views)
+	if err != nil {
+		return errors.Wrapf(err, "%s", failedManifestScan)
+	}
+	reportFormats, _ := cmd.Flags().GetString(commonParams.TargetFormatFlag)
+	if !strings.Contains(strings.ToLower(reportFormats), strings.ToLower(printer.FormatSummaryJSON)) {
+		return nil
+	}
+	targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
+	targetPath, _ := cmd.Flags().GetString(commonParams.TargetPathFlag)
+	err = createDirectory(targetPath)
+	if err != nil {
+		return err
+	}
+	summaryRpt := createTargetName(fmt.Sprintf(manifestSummaryName, targetFile), targetPath, "json")
+	log.Println("Creating manifest summary JSON Report: ", summaryRpt)
+	summaryJSON, err := json.Marshal(views)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to serialize the manifest summary", failedManifestScan)
+	}
+	f, err := os.Create(summaryRpt)
+	if err != nil {
+		return errors.Wrapf(err, "%s: failed to create target file", failedManifestScan)
+	}
+	_, _ = fmt.Fprintln(f, string(summaryJSON))
+	_ = f.Close()
+	return nil
+}
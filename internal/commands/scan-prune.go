@@ -0,0 +1,333 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	failedPruning        = "Failed pruning scans"
+	scanPruneNoRules     = "Please provide at least one of --keep-last, --older-than or --statuses"
+	scanPruneDeleted     = "Deleted"
+	scanPrunePlanned     = "Planned"
+	scanPruneConfirm     = "Delete %d scans? (y/N) "
+	scanPruneAborted     = "Aborted, no scan was deleted"
+	scanPruneNothing     = "No scan matches the retention rules"
+	scanPruneDateFormat  = time.RFC3339
+	scanPruneConfirmWord = "y"
+)
+
+type scanPruneRules struct {
+	KeepLast  int
+	OlderThan int
+	Statuses  []string
+	// NewerThan narrows the other rules to the recent scans, it is not a rule on its own
+	NewerThan int
+}
+
+type scanPruneView struct {
+	ScanID    string    `format:"name:Scan ID"`
+	ProjectID string    `format:"name:Project ID"`
+	Branch    string    `format:"name:Branch"`
+	Status    string    `format:"name:Status"`
+	CreatedAt time.Time `format:"name:Created at;time:01-02-06 15:04:05"`
+	Reason    string    `format:"name:Reason"`
+	Result    string    `format:"name:Result"`
+}
+
+func scanPruneSubCommand(scansWrapper wrappers.ScansWrapper, projectsWrapper wrappers.ProjectsWrapper) *cobra.Command {
+	pruneScanCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old scans according to retention rules",
+		Long: "The prune command deletes the scans matching all the given retention rules. " +
+			"The scans to delete are always listed first and a confirmation is required unless --yes is given. " +
+			"The latest completed scan of the main branch of a project is never deleted.",
+		Example: heredoc.Doc(
+			`
+			$ cx scan prune --keep-last 10 --dry-run
+			$ cx scan prune --project-id <project Id> --older-than 90 --yes
+			$ cx scan prune --statuses Failed,Canceled
+			$ cx scan prune --older-than 30 --newer-than 90 --format json --yes
+		`,
+		),
+		RunE: runScanPruneCommand(scansWrapper, projectsWrapper),
+	}
+	pruneScanCmd.PersistentFlags().String(commonParams.ProjectIDFlag, "", "Only prune the scans of this project")
+	pruneScanCmd.PersistentFlags().Int(commonParams.KeepLastFlag, 0, commonParams.KeepLastFlagUsage)
+	pruneScanCmd.PersistentFlags().Int(commonParams.OlderThanFlag, 0, commonParams.OlderThanFlagUsage)
+	pruneScanCmd.PersistentFlags().Int(commonParams.NewerThanFlag, 0, commonParams.NewerThanFlagUsage)
+	pruneScanCmd.PersistentFlags().StringSlice(commonParams.PruneStatusesFlag, []string{}, commonParams.PruneStatusesFlagUsage)
+	pruneScanCmd.PersistentFlags().Bool(commonParams.DryRunFlag, false, commonParams.DryRunFlagUsage)
+	pruneScanCmd.PersistentFlags().Bool(commonParams.YesFlag, false, commonParams.YesFlagUsage)
+	addFormatFlag(pruneScanCmd, printer.FormatTable, printer.FormatJSON, printer.FormatList)
+
+	return pruneScanCmd
+}
+
+func runScanPruneCommand(
+	scansWrapper wrappers.ScansWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString(commonParams.ProjectIDFlag)
+		dryRun, _ := cmd.Flags().GetBool(commonParams.DryRunFlag)
+		yes, _ := cmd.Flags().GetBool(commonParams.YesFlag)
+		rules, err := getScanPruneRules(cmd)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruning)
+		}
+
+		now := time.Now()
+		scans, err := getAllScans(scansWrapper, scanPruneParams(projectID, rules, now))
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruning)
+		}
+		protected, err := getProtectedScans(scansWrapper, projectsWrapper, scans)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedPruning)
+		}
+
+		format, _ := cmd.Flags().GetString(commonParams.FormatFlag)
+		jsonFormat := printer.IsFormat(format, printer.FormatJSON)
+		views := planScanPrune(scans, protected, rules, now)
+		if len(views) == 0 {
+			if jsonFormat {
+				return printByFormat(cmd, []scanPruneView{})
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), scanPruneNothing)
+			return nil
+		}
+		if dryRun {
+			return printByFormat(cmd, views)
+		}
+		// JSON output is a single document with the final views, so the plan and prompt only go to the terminal
+		promptOut := cmd.ErrOrStderr()
+		if !jsonFormat {
+			if err = printByFormat(cmd, views); err != nil {
+				return err
+			}
+			promptOut = cmd.OutOrStdout()
+		}
+		if !yes && !confirmScanPrune(cmd.InOrStdin(), promptOut, len(views)) {
+			if jsonFormat {
+				return printByFormat(cmd, views)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), scanPruneAborted)
+			return nil
+		}
+
+		failed := 0
+		for i := range views {
+			views[i].Result = scanPruneDeleted
+			errorModel, deleteErr := scansWrapper.Delete(views[i].ScanID)
+			if deleteErr != nil {
+				views[i].Result = deleteErr.Error()
+				failed++
+			} else if errorModel != nil {
+				views[i].Result = fmt.Sprintf("%d: %s", errorModel.Code, errorModel.Message)
+				failed++
+			}
+		}
+		if err = printByFormat(cmd, views); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return errors.Errorf("%s: %d of %d scans could not be deleted", failedPruning, failed, len(views))
+		}
+		return nil
+	}
+}
+
+func getScanPruneRules(cmd *cobra.Command) (scanPruneRules, error) {
+	keepLast, _ := cmd.Flags().GetInt(commonParams.KeepLastFlag)
+	olderThan, _ := cmd.Flags().GetInt(commonParams.OlderThanFlag)
+	newerThan, _ := cmd.Flags().GetInt(commonParams.NewerThanFlag)
+	statuses, _ := cmd.Flags().GetStringSlice(commonParams.PruneStatusesFlag)
+	rules := scanPruneRules{}
+	if cmd.Flags().Changed(commonParams.KeepLastFlag) {
+		if keepLast < 1 {
+			return rules, errors.Errorf("--%s must be at least 1", commonParams.KeepLastFlag)
+		}
+		rules.KeepLast = keepLast
+	}
+	if cmd.Flags().Changed(commonParams.OlderThanFlag) {
+		if olderThan < 1 {
+			return rules, errors.Errorf("--%s must be at least 1", commonParams.OlderThanFlag)
+		}
+		rules.OlderThan = olderThan
+	}
+	if cmd.Flags().Changed(commonParams.NewerThanFlag) {
+		if newerThan <= rules.OlderThan {
+			return rules, errors.Errorf("--%s must be higher than --%s", commonParams.NewerThanFlag, commonParams.OlderThanFlag)
+		}
+		rules.NewerThan = newerThan
+	}
+	for _, status := range statuses {
+		status = strings.TrimSpace(status)
+		if status != "" {
+			rules.Statuses = append(rules.Statuses, status)
+		}
+	}
+	if rules.KeepLast == 0 && rules.OlderThan == 0 && len(rules.Statuses) == 0 {
+		return rules, errors.New(scanPruneNoRules)
+	}
+	return rules, nil
+}
+
+// scanPruneParams filters the scans listing with the rules, as far as the ranking of --keep-last allows
+func scanPruneParams(projectID string, rules scanPruneRules, now time.Time) map[string]string {
+	params := map[string]string{}
+	if projectID != "" {
+		params[commonParams.ProjectIDQueryParam] = projectID
+	}
+	// Like the dates, the statuses are left to planScanPrune when --keep-last ranks the scans of every status
+	if len(rules.Statuses) > 0 && rules.KeepLast == 0 {
+		params[commonParams.StatusesQueryParam] = strings.Join(rules.Statuses, ",")
+	}
+	// The older scans of a branch never change the rank of the newer ones
+	if rules.NewerThan > 0 {
+		params[commonParams.FromDateQueryParam] = scanPruneCutoff(now, rules.NewerThan).UTC().Format(scanPruneDateFormat)
+	}
+	// Ranking the scans of a branch needs its newer scans as well
+	if rules.OlderThan > 0 && rules.KeepLast == 0 {
+		params[commonParams.ToDateQueryParam] = scanPruneCutoff(now, rules.OlderThan).UTC().Format(scanPruneDateFormat)
+	}
+	return params
+}
+
+// getAllScans streams every page of the scans matching the params
+func getAllScans(scansWrapper wrappers.ScansWrapper, params map[string]string) ([]wrappers.ScanResponseModel, error) {
+	var scans []wrappers.ScanResponseModel
+	it := wrappers.NewScansIterator(scansWrapper, params, wrappers.DefaultPageSize)
+	for it.Next() {
+		scans = append(scans, *it.Scan())
+	}
+	if it.Err() != nil {
+		return nil, errors.Wrapf(it.Err(), "%s", failedGettingAll)
+	}
+	return scans, nil
+}
+
+// getProtectedScans returns the latest completed scan of the main branch of every project of the scans. When a
+// project has no main branch its latest completed scan is protected instead.
+func getProtectedScans(
+	scansWrapper wrappers.ScansWrapper,
+	projectsWrapper wrappers.ProjectsWrapper,
+	scans []wrappers.ScanResponseModel,
+) (map[string]bool, error) {
+	protected := make(map[string]bool)
+	visited := make(map[string]bool)
+	for i := range scans {
+		projectID := scans[i].ProjectID
+		if projectID == "" || visited[projectID] {
+			continue
+		}
+		visited[projectID] = true
+		project, errorModel, err := projectsWrapper.GetByID(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if errorModel != nil {
+			return nil, errors.Errorf(ErrorCodeFormat, failedGettingProj, errorModel.Code, errorModel.Message)
+		}
+		branch := ""
+		if project != nil {
+			branch = project.MainBranch
+		}
+		latest, err := getLatestBranchScan(scansWrapper, projectID, branch)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil {
+			protected[latest.ID] = true
+		}
+	}
+	return protected, nil
+}
+
+// planScanPrune returns the scans matching all the rules, except the protected ones
+func planScanPrune(
+	scans []wrappers.ScanResponseModel,
+	protected map[string]bool,
+	rules scanPruneRules,
+	now time.Time,
+) []scanPruneView {
+	sorted := make([]wrappers.ScanResponseModel, len(scans))
+	copy(sorted, scans)
+	sort.SliceStable(
+		sorted, func(i, j int) bool {
+			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+		},
+	)
+
+	var views []scanPruneView
+	ranks := make(map[string]int)
+	for i := range sorted {
+		scan := &sorted[i]
+		branchKey := scan.ProjectID + "/" + scan.Branch
+		ranks[branchKey]++
+		var reasons []string
+		if rules.KeepLast > 0 {
+			if ranks[branchKey] <= rules.KeepLast {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("not in the last %d scans", rules.KeepLast))
+		}
+		if rules.OlderThan > 0 {
+			if !scan.CreatedAt.Before(scanPruneCutoff(now, rules.OlderThan)) {
+				continue
+			}
+			reasons = append(reasons, fmt.Sprintf("older than %d days", rules.OlderThan))
+		}
+		if rules.NewerThan > 0 && scan.CreatedAt.Before(scanPruneCutoff(now, rules.NewerThan)) {
+			continue
+		}
+		if len(rules.Statuses) > 0 {
+			if !utils.ContainsFold(rules.Statuses, string(scan.Status)) {
+				continue
+			}
+			reasons = append(reasons, "status "+string(scan.Status))
+		}
+		if protected[scan.ID] {
+			continue
+		}
+		views = append(
+			views, scanPruneView{
+				ScanID:    scan.ID,
+				ProjectID: scan.ProjectID,
+				Branch:    scan.Branch,
+				Status:    string(scan.Status),
+				CreatedAt: scan.CreatedAt,
+				Reason:    strings.Join(reasons, ", "),
+				Result:    scanPrunePlanned,
+			},
+		)
+	}
+	return views
+}
+
+func scanPruneCutoff(now time.Time, days int) time.Time {
+	return now.Add(-time.Duration(days) * hoursPerDay * time.Hour)
+}
+
+// confirmScanPrune asks for a confirmation, anything but y aborts
+func confirmScanPrune(in io.Reader, out io.Writer, count int) bool {
+	fmt.Fprintf(out, scanPruneConfirm, count)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(answer), scanPruneConfirmWord)
+}
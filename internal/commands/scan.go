@@ -18,6 +18,7 @@ import (
 
 	"github.com/checkmarx/ast-cli/internal/commands/util"
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/commands/util/progress"
 	"github.com/checkmarx/ast-cli/internal/logger"
 	"github.com/google/shlex"
 	"github.com/google/uuid"
@@ -38,6 +39,8 @@ const (
 	failedDeleting                  = "Failed deleting a scan"
 	failedCanceling                 = "Failed canceling a scan"
 	failedGettingAll                = "Failed listing"
+	failedWatching                  = "Failed watching a scan"
+	latestScanSort                  = "-created_at"
 	thresholdLog                    = "%s: Limit = %d, Current = %v"
 	thresholdMsgLog                 = "Threshold check finished with status %s : %s"
 	mbBytes                         = 1024.0 * 1024.0
@@ -73,11 +76,10 @@ const (
 	invalidEngineMessage            = "Please verify if engine is installed and running"
 	cleanupMaxRetries               = 3
 	cleanupRetryWaitSeconds         = 15
+	defaultScanTypes                = "sast,kics,sca"
 )
 
 var (
-	scaResolverResultsFile  = ""
-	actualScanTypes         = "sast,kics,sca"
 	filterScanListFlagUsage = fmt.Sprintf(
 		"Filter the list of scans. Use ';' as the delimeter for arrays. Available filters are: %s",
 		strings.Join(
@@ -105,6 +107,9 @@ func NewScanCommand(
 	projectsWrapper wrappers.ProjectsWrapper,
 	logsWrapper wrappers.LogsWrapper,
 	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
 ) *cobra.Command {
 	scanCmd := &cobra.Command{
 		Use:   "scan",
@@ -119,7 +124,18 @@ func NewScanCommand(
 		},
 	}
 
-	createScanCmd := scanCreateSubCommand(scansWrapper, uploadsWrapper, resultsWrapper, projectsWrapper, groupsWrapper)
+	createScanCmd := scanCreateSubCommand(
+		scansWrapper,
+		uploadsWrapper,
+		resultsWrapper,
+		projectsWrapper,
+		groupsWrapper,
+		hooksWrapper,
+		learnMoreWrapper,
+		codeBashingWrapper,
+	)
+
+	watchScanCmd := scanWatchSubCommand(scansWrapper, resultsWrapper, hooksWrapper)
 
 	listScansCmd := scanListSubCommand(scansWrapper)
 
@@ -137,6 +153,8 @@ func NewScanCommand(
 
 	kicsRealtimeCmd := scanRealtimeSubCommand()
 
+	pruneScanCmd := scanPruneSubCommand(scansWrapper, projectsWrapper)
+
 	addFormatFlagToMultipleCommands(
 		[]*cobra.Command{listScansCmd, showScanCmd, workflowScanCmd},
 		printer.FormatTable, printer.FormatList, printer.FormatJSON,
@@ -144,8 +162,12 @@ func NewScanCommand(
 	addScanInfoFormatFlag(
 		createScanCmd, printer.FormatList, printer.FormatTable, printer.FormatJSON,
 	)
+	addScanInfoFormatFlag(
+		watchScanCmd, printer.FormatList, printer.FormatTable, printer.FormatJSON,
+	)
 	scanCmd.AddCommand(
 		createScanCmd,
+		watchScanCmd,
 		showScanCmd,
 		workflowScanCmd,
 		listScansCmd,
@@ -154,6 +176,7 @@ func NewScanCommand(
 		tagsCmd,
 		logsCmd,
 		kicsRealtimeCmd,
+		pruneScanCmd,
 	)
 	return scanCmd
 }
@@ -340,6 +363,7 @@ func scanListSubCommand(scansWrapper wrappers.ScansWrapper) *cobra.Command {
 		Example: heredoc.Doc(
 			`
 			$ cx scan list
+			$ cx scan list --all --filter "statuses=Completed,limit=500"
 		`,
 		),
 		Annotations: map[string]string{
@@ -352,6 +376,7 @@ func scanListSubCommand(scansWrapper wrappers.ScansWrapper) *cobra.Command {
 		RunE: runListScansCommand(scansWrapper),
 	}
 	listScansCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterScanListFlagUsage)
+	addAllPagesFlags(listScansCmd)
 	return listScansCmd
 }
 
@@ -361,6 +386,9 @@ func scanCreateSubCommand(
 	resultsWrapper wrappers.ResultsWrapper,
 	projectsWrapper wrappers.ProjectsWrapper,
 	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
 ) *cobra.Command {
 	createScanCmd := &cobra.Command{
 		Use:   "create",
@@ -369,6 +397,8 @@ func scanCreateSubCommand(
 		Example: heredoc.Doc(
 			`
 			$ cx scan create --project-name <Project Name> -s <path or repository url>
+			$ cx scan create -s <path with a .checkmarx.yaml file>
+			$ cx scan create --manifest services.yaml --manifest-parallelism 4
 		`,
 		),
 		Annotations: map[string]string{
@@ -378,20 +408,26 @@ func scanCreateSubCommand(
 			`,
 			),
 		},
-		RunE: runCreateScanCommand(scansWrapper, uploadsWrapper, resultsWrapper, projectsWrapper, groupsWrapper),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			err := applyScanConfigFile(cmd)
+			if err != nil {
+				return err
+			}
+			return validateProjectNameOrManifest(cmd)
+		},
+		RunE: runCreateScanCommand(
+			scansWrapper,
+			uploadsWrapper,
+			resultsWrapper,
+			projectsWrapper,
+			groupsWrapper,
+			hooksWrapper,
+			learnMoreWrapper,
+			codeBashingWrapper,
+		),
 	}
 	createScanCmd.PersistentFlags().Bool(commonParams.AsyncFlag, false, "Do not wait for scan completion")
-	createScanCmd.PersistentFlags().IntP(
-		commonParams.WaitDelayFlag,
-		"",
-		commonParams.WaitDelayDefault,
-		"Polling wait time in seconds",
-	)
-	createScanCmd.PersistentFlags().Int(
-		commonParams.ScanTimeoutFlag,
-		0,
-		"Cancel the scan and fail after the timeout in minutes",
-	)
+	addScanWaitFlags(createScanCmd)
 	createScanCmd.PersistentFlags().StringP(
 		commonParams.SourcesFlag,
 		commonParams.SourcesFlagSh,
@@ -412,10 +448,12 @@ func scanCreateSubCommand(
 			" Add a comma separated list of extra inclusions, ex: *zip,file.txt",
 	)
 	createScanCmd.PersistentFlags().String(commonParams.ProjectName, "", "Name of the project")
-	err := createScanCmd.MarkPersistentFlagRequired(commonParams.ProjectName)
-	if err != nil {
-		log.Fatal(err)
-	}
+	createScanCmd.PersistentFlags().String(commonParams.ManifestFlag, "", commonParams.ManifestFlagUsage)
+	createScanCmd.PersistentFlags().Int(
+		commonParams.ManifestParallelismFlag,
+		commonParams.ManifestParallelismDefault,
+		commonParams.ManifestParallelismUsage,
+	)
 	createScanCmd.PersistentFlags().Bool(
 		commonParams.IncrementalSast,
 		false,
@@ -442,25 +480,13 @@ func scanCreateSubCommand(
 	createScanCmd.PersistentFlags().String(commonParams.KicsFilterFlag, "", commonParams.KicsFilterUsage)
 	createScanCmd.PersistentFlags().String(commonParams.KicsPlatformsFlag, "", commonParams.KicsPlatformsFlagUsage)
 	createScanCmd.PersistentFlags().String(commonParams.ScaFilterFlag, "", commonParams.ScaFilterUsage)
-	addResultFormatFlag(
-		createScanCmd,
-		printer.FormatSummaryConsole,
-		printer.FormatJSON,
-		printer.FormatSummary,
-		printer.FormatSarif,
-	)
-	createScanCmd.PersistentFlags().String(commonParams.TargetFlag, "cx_result", "Output file")
-	createScanCmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
-	createScanCmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	addScanReportFlags(createScanCmd)
+	createScanCmd.PersistentFlags().Bool(commonParams.EnrichFlag, false, commonParams.EnrichFlagUsage)
+	addScanHookFlags(createScanCmd)
 	createScanCmd.PersistentFlags().String(commonParams.ProjectGroupList, "", "List of groups to associate to project")
 	createScanCmd.PersistentFlags().String(commonParams.ProjectTagList, "", "List of tags to associate to project")
-	createScanCmd.PersistentFlags().String(
-		commonParams.Threshold,
-		"",
-		"Local build threshold. Format <engine>-<severity>=<limit>",
-	)
 	// Link the environment variables to the CLI argument(s).
-	err = viper.BindPFlag(commonParams.BranchKey, createScanCmd.PersistentFlags().Lookup(commonParams.BranchFlag))
+	err := viper.BindPFlag(commonParams.BranchKey, createScanCmd.PersistentFlags().Lookup(commonParams.BranchFlag))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -473,6 +499,89 @@ func scanCreateSubCommand(
 	return createScanCmd
 }
 
+func scanWatchSubCommand(
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+) *cobra.Command {
+	watchScanCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Wait for an existing scan to finish",
+		Long: "The watch command attaches to an existing scan, waits for it to finish and then creates the reports " +
+			"and applies the thresholds like the create command does.",
+		Example: heredoc.Doc(
+			`
+			$ cx scan watch --scan-id <scan Id> --report-format sarif --threshold "sast-high=1"
+		`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				https://checkmarx.atlassian.net/wiki/x/WguYtw
+			`,
+			),
+		},
+		RunE: runWatchScanCommand(scansWrapper, resultsWrapper, hooksWrapper),
+	}
+	addScanIDFlag(watchScanCmd, "Scan ID to watch.")
+	addScanWaitFlags(watchScanCmd)
+	addScanReportFlags(watchScanCmd)
+	addScanHookFlags(watchScanCmd)
+	return watchScanCmd
+}
+
+func addScanWaitFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().IntP(
+		commonParams.WaitDelayFlag,
+		"",
+		commonParams.WaitDelayDefault,
+		"Polling wait time in seconds",
+	)
+	cmd.PersistentFlags().Int(
+		commonParams.ScanTimeoutFlag,
+		0,
+		"Cancel the scan and fail after the timeout in minutes",
+	)
+	cmd.PersistentFlags().Bool(commonParams.NoProgressFlag, false, commonParams.NoProgressFlagUsage)
+}
+
+func addScanReportFlags(cmd *cobra.Command) {
+	addResultFormatFlag(
+		cmd,
+		printer.FormatSummaryConsole,
+		printer.FormatJSON,
+		printer.FormatSummary,
+		printer.FormatSarif,
+	)
+	cmd.PersistentFlags().String(commonParams.TargetFlag, "cx_result", "Output file")
+	cmd.PersistentFlags().String(commonParams.TargetPathFlag, ".", "Output Path")
+	cmd.PersistentFlags().StringSlice(commonParams.FilterFlag, []string{}, filterResultsListFlagUsage)
+	cmd.PersistentFlags().String(
+		commonParams.Threshold,
+		"",
+		"Local build threshold. Format <engine>-<severity>=<limit>",
+	)
+}
+
+// validateProjectNameOrManifest replaces the required flag check of --project-name, which is not needed when the
+// projects come from a manifest
+func validateProjectNameOrManifest(cmd *cobra.Command) error {
+	projectName, _ := cmd.Flags().GetString(commonParams.ProjectName)
+	manifestPath, _ := cmd.Flags().GetString(commonParams.ManifestFlag)
+	if manifestPath != "" && (projectName != "" || cmd.Flags().Changed(commonParams.SourcesFlag)) {
+		return errors.Errorf(
+			"--%s cannot be combined with --%s or --%s",
+			commonParams.ManifestFlag,
+			commonParams.ProjectName,
+			commonParams.SourcesFlag,
+		)
+	}
+	if manifestPath == "" && !cmd.Flags().Changed(commonParams.ProjectName) {
+		return errors.Errorf("required flag(s) \"%s\" not set", commonParams.ProjectName)
+	}
+	return nil
+}
+
 func findProject(
 	projectName string,
 	cmd *cobra.Command,
@@ -566,6 +675,7 @@ func createTagMap(tagListStr string) map[string]string {
 func setupScanTypeProjectAndConfig(
 	input *[]byte,
 	cmd *cobra.Command,
+	scanTypes string,
 	projectsWrapper wrappers.ProjectsWrapper,
 	groupsWrapper wrappers.GroupsWrapper,
 ) error {
@@ -603,15 +713,15 @@ func setupScanTypeProjectAndConfig(
 			return err
 		}
 	}
-	sastConfig := addSastScan(cmd)
+	sastConfig := addSastScan(cmd, scanTypes)
 	if sastConfig != nil {
 		configArr = append(configArr, sastConfig)
 	}
-	var kicsConfig = addKicsScan(cmd)
+	var kicsConfig = addKicsScan(cmd, scanTypes)
 	if kicsConfig != nil {
 		configArr = append(configArr, kicsConfig)
 	}
-	var scaConfig = addScaScan(cmd)
+	var scaConfig = addScaScan(cmd, scanTypes)
 	if scaConfig != nil {
 		configArr = append(configArr, scaConfig)
 	}
@@ -620,8 +730,8 @@ func setupScanTypeProjectAndConfig(
 	return err
 }
 
-func addSastScan(cmd *cobra.Command) map[string]interface{} {
-	if scanTypeEnabled(commonParams.SastType) {
+func addSastScan(cmd *cobra.Command, scanTypes string) map[string]interface{} {
+	if scanTypeEnabled(scanTypes, commonParams.SastType) {
 		sastMapConfig := make(map[string]interface{})
 		sastConfig := wrappers.SastConfig{}
 		sastMapConfig["type"] = commonParams.SastType
@@ -635,8 +745,8 @@ func addSastScan(cmd *cobra.Command) map[string]interface{} {
 	return nil
 }
 
-func addKicsScan(cmd *cobra.Command) map[string]interface{} {
-	if scanTypeEnabled(commonParams.KicsType) {
+func addKicsScan(cmd *cobra.Command, scanTypes string) map[string]interface{} {
+	if scanTypeEnabled(scanTypes, commonParams.KicsType) {
 		kicsMapConfig := make(map[string]interface{})
 		kicsConfig := wrappers.KicsConfig{}
 		kicsMapConfig["type"] = commonParams.KicsType
@@ -648,8 +758,8 @@ func addKicsScan(cmd *cobra.Command) map[string]interface{} {
 	return nil
 }
 
-func addScaScan(cmd *cobra.Command) map[string]interface{} {
-	if scanTypeEnabled(commonParams.ScaType) {
+func addScaScan(cmd *cobra.Command, scanTypes string) map[string]interface{} {
+	if scanTypeEnabled(scanTypes, commonParams.ScaType) {
 		scaMapConfig := make(map[string]interface{})
 		scaConfig := wrappers.ScaConfig{}
 		scaMapConfig["type"] = commonParams.ScaType
@@ -660,30 +770,24 @@ func addScaScan(cmd *cobra.Command) map[string]interface{} {
 	return nil
 }
 
-func validateScanTypes(cmd *cobra.Command) {
-	userScanTypes, _ := cmd.Flags().GetString(commonParams.ScanTypes)
-	if len(userScanTypes) > 0 {
-		actualScanTypes = userScanTypes
+// getScanTypes returns the scan types requested by the command, or the default ones when none were given
+func getScanTypes(cmd *cobra.Command) (string, error) {
+	scanTypes, _ := cmd.Flags().GetString(commonParams.ScanTypes)
+	if len(scanTypes) == 0 {
+		scanTypes = defaultScanTypes
 	}
-
-	scanTypes := strings.Split(actualScanTypes, ",")
-	for _, scanType := range scanTypes {
-		isValid := false
-		if strings.EqualFold(strings.TrimSpace(scanType), commonParams.SastType) ||
-			strings.EqualFold(strings.TrimSpace(scanType), commonParams.KicsType) ||
-			strings.EqualFold(strings.TrimSpace(scanType), commonParams.ScaType) {
-			isValid = true
-		}
-		if !isValid {
-			log.Println(fmt.Sprintf("Error: unknown scan type: %s", scanType))
-			os.Exit(1)
+	for _, scanType := range strings.Split(scanTypes, ",") {
+		if !strings.EqualFold(strings.TrimSpace(scanType), commonParams.SastType) &&
+			!strings.EqualFold(strings.TrimSpace(scanType), commonParams.KicsType) &&
+			!strings.EqualFold(strings.TrimSpace(scanType), commonParams.ScaType) {
+			return "", errors.Errorf("%s: unknown scan type: %s", failedCreating, scanType)
 		}
 	}
+	return scanTypes, nil
 }
 
-func scanTypeEnabled(scanType string) bool {
-	scanTypes := strings.Split(actualScanTypes, ",")
-	for _, a := range scanTypes {
+func scanTypeEnabled(scanTypes, scanType string) bool {
+	for _, a := range strings.Split(scanTypes, ",") {
 		if strings.EqualFold(strings.TrimSpace(a), scanType) {
 			return true
 		}
@@ -691,8 +795,7 @@ func scanTypeEnabled(scanType string) bool {
 	return false
 }
 
-func compressFolder(sourceDir, filter, userIncludeFilter, scaResolver string) (string, error) {
-	scaToolPath := scaResolver
+func compressFolder(sourceDir, filter, userIncludeFilter, scaResultsFile string) (string, error) {
 	outputFile, err := ioutil.TempFile(os.TempDir(), "cx-*.zip")
 	if err != nil {
 		return "", errors.Wrapf(err, "Cannot source code temp file.")
@@ -702,8 +805,8 @@ func compressFolder(sourceDir, filter, userIncludeFilter, scaResolver string) (s
 	if err != nil {
 		return "", err
 	}
-	if len(scaToolPath) > 0 && len(scaResolverResultsFile) > 0 {
-		err = addScaResults(zipWriter)
+	if len(scaResultsFile) > 0 {
+		err = addScaResults(zipWriter, scaResultsFile)
 		if err != nil {
 			return "", err
 		}
@@ -879,16 +982,18 @@ func filterMatched(filters []string, fileName string) bool {
 	return matched
 }
 
-func runScaResolver(sourceDir, scaResolver, scaResolverParams string) error {
+// runScaResolver runs the SCA resolver over the sources and returns the file with its results, or an empty path
+// when no resolver is configured
+func runScaResolver(sourceDir, scaResolver, scaResolverParams string) (string, error) {
 	if len(scaResolver) > 0 {
 		scaFile, err := ioutil.TempFile("", "sca")
-		scaResolverResultsFile = scaFile.Name() + ".json"
 		if err != nil {
-			return err
+			return "", err
 		}
+		scaResolverResultsFile := scaFile.Name() + ".json"
 		scaResolverParsedParams, err := shlex.Split(scaResolverParams)
 		if err != nil {
-			return err
+			return "", err
 		}
 		args := []string{
 			"offline",
@@ -903,14 +1008,15 @@ func runScaResolver(sourceDir, scaResolver, scaResolverParams string) error {
 		log.Println(fmt.Sprintf("Using SCA resolver: %s %v", scaResolver, args))
 		out, err := exec.Command(scaResolver, args...).Output()
 		if err != nil {
-			return errors.Errorf("%s", err)
+			return "", errors.Errorf("%s", err)
 		}
 		logger.PrintIfVerbose(string(out))
+		return scaResolverResultsFile, nil
 	}
-	return nil
+	return "", nil
 }
 
-func addScaResults(zipWriter *zip.Writer) error {
+func addScaResults(zipWriter *zip.Writer, scaResolverResultsFile string) error {
 	logger.PrintIfVerbose("Included SCA Results: " + ".cxsca-results.json")
 	dat, err := ioutil.ReadFile(scaResolverResultsFile)
 	_ = os.Remove(scaResolverResultsFile)
@@ -928,7 +1034,7 @@ func addScaResults(zipWriter *zip.Writer) error {
 	return nil
 }
 
-func getUploadURLFromSource(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper) (
+func getUploadURLFromSource(cmd *cobra.Command, scanTypes string, uploadsWrapper wrappers.UploadsWrapper) (
 	url, zipFilePath string,
 	err error,
 ) {
@@ -955,12 +1061,13 @@ func getUploadURLFromSource(cmd *cobra.Command, uploadsWrapper wrappers.UploadsW
 
 	if directoryPath != "" {
 		var dirPathErr error
+		var scaResultsFile string
 
 		scaResolverParams, scaResolver := getScaResolverFlags(cmd)
 
 		// Make sure scaResolver only runs in sca type of scans
-		if strings.Contains(actualScanTypes, commonParams.ScaType) {
-			dirPathErr = runScaResolver(directoryPath, scaResolver, scaResolverParams)
+		if strings.Contains(scanTypes, commonParams.ScaType) {
+			scaResultsFile, dirPathErr = runScaResolver(directoryPath, scaResolver, scaResolverParams)
 			if dirPathErr != nil {
 				if unzip {
 					_ = cleanTempUnzipDirectory(directoryPath)
@@ -969,7 +1076,7 @@ func getUploadURLFromSource(cmd *cobra.Command, uploadsWrapper wrappers.UploadsW
 			}
 		}
 
-		zipFilePath, dirPathErr = compressFolder(directoryPath, sourceDirFilter, userIncludeFilter, scaResolver)
+		zipFilePath, dirPathErr = compressFolder(directoryPath, sourceDirFilter, userIncludeFilter, scaResultsFile)
 		if unzip {
 			dirRemovalErr := cleanTempUnzipDirectory(directoryPath)
 			if dirRemovalErr != nil {
@@ -1111,17 +1218,42 @@ func runCreateScanCommand(
 	resultsWrapper wrappers.ResultsWrapper,
 	projectsWrapper wrappers.ProjectsWrapper,
 	groupsWrapper wrappers.GroupsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	codeBashingWrapper wrappers.CodeBashingWrapper,
 ) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		branch := viper.GetString(commonParams.BranchKey)
+		manifestPath, _ := cmd.Flags().GetString(commonParams.ManifestFlag)
+		if manifestPath != "" {
+			return runManifestScans(
+				cmd,
+				manifestPath,
+				scansWrapper,
+				uploadsWrapper,
+				resultsWrapper,
+				projectsWrapper,
+				groupsWrapper,
+				hooksWrapper,
+			)
+		}
+		branch := getScanBranch(cmd)
 		if branch == "" {
 			return errors.Errorf("%s: Please provide a branch", failedCreating)
 		}
+		scanTypes, err := getScanTypes(cmd)
+		if err != nil {
+			return err
+		}
 		timeoutMinutes, _ := cmd.Flags().GetInt(commonParams.ScanTimeoutFlag)
 		if timeoutMinutes < 0 {
 			return errors.Errorf("--%s should be equal or higher than 0", commonParams.ScanTimeoutFlag)
 		}
-		scanModel, zipFilePath, err := createScanModel(cmd, uploadsWrapper, projectsWrapper, groupsWrapper)
+		hooks, err := newScanHooks(cmd, hooksWrapper, scansWrapper, resultsWrapper)
+		if err != nil {
+			return err
+		}
+		enrichers := guidanceEnrichers(cmd, learnMoreWrapper, codeBashingWrapper)
+		scanModel, zipFilePath, err := createScanModel(cmd, scanTypes, branch, uploadsWrapper, projectsWrapper, groupsWrapper)
 		if err != nil {
 			return errors.Errorf("%s", err)
 		}
@@ -1138,27 +1270,28 @@ func runCreateScanCommand(
 			if err != nil {
 				return errors.Wrapf(err, "%s\n", failedCreating)
 			}
+			hooks.fire(wrappers.HookScanCreated, scanResponseModel, "")
 		}
 		// Wait until the scan is done: Queued, Running
 		AsyncFlag, _ := cmd.Flags().GetBool(commonParams.AsyncFlag)
 		if !AsyncFlag {
 			waitDelay, _ := cmd.Flags().GetInt(commonParams.WaitDelayFlag)
-			err = handleWait(cmd, scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper)
+			err = handleWait(cmd, scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper, hooks)
 			if err != nil {
 				return err
 			}
 
-			err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper)
+			err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper, enrichers...)
 			if err != nil {
 				return err
 			}
 
-			err = applyThreshold(cmd, resultsWrapper, scanResponseModel)
+			err = applyThreshold(cmd, resultsWrapper, scanResponseModel, hooks)
 			if err != nil {
 				return err
 			}
 		} else {
-			err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper)
+			err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper, enrichers...)
 			if err != nil {
 				return err
 			}
@@ -1170,6 +1303,61 @@ func runCreateScanCommand(
 	}
 }
 
+func runWatchScanCommand(
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	hooksWrapper wrappers.HooksWrapper,
+) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		scanID, _ := cmd.Flags().GetString(commonParams.ScanIDFlag)
+		if scanID == "" {
+			return errors.Errorf("%s: Please provide a scan ID", failedWatching)
+		}
+		timeoutMinutes, _ := cmd.Flags().GetInt(commonParams.ScanTimeoutFlag)
+		if timeoutMinutes < 0 {
+			return errors.Errorf("--%s should be equal or higher than 0", commonParams.ScanTimeoutFlag)
+		}
+		hooks, err := newScanHooks(cmd, hooksWrapper, scansWrapper, resultsWrapper)
+		if err != nil {
+			return err
+		}
+		scanResponseModel, errorModel, err := scansWrapper.GetByID(scanID)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedWatching)
+		}
+		if errorModel != nil {
+			return errors.Errorf(ErrorCodeFormat, failedWatching, errorModel.Code, errorModel.Message)
+		}
+		err = printByScanInfoFormat(cmd, toScanView(scanResponseModel))
+		if err != nil {
+			return errors.Wrapf(err, "%s\n", failedWatching)
+		}
+		if scanResponseModel.Status == wrappers.ScanRunning || scanResponseModel.Status == wrappers.ScanQueued {
+			waitDelay, _ := cmd.Flags().GetInt(commonParams.WaitDelayFlag)
+			err = handleWait(cmd, scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper, hooks)
+		} else {
+			// The scan already finished, check its final status the same way the wait does. Its lifecycle hooks were
+			// fired by whoever waited for it, they are not sent again.
+			_, err = isScanRunning(
+				scansWrapper,
+				resultsWrapper,
+				scanResponseModel.ID,
+				cmd,
+				progress.NewLogRenderer(),
+				nil,
+			)
+		}
+		if err != nil {
+			return err
+		}
+		err = createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper)
+		if err != nil {
+			return err
+		}
+		return applyThreshold(cmd, resultsWrapper, scanResponseModel, hooks)
+	}
+}
+
 func enrichScanResponseModel(
 	cmd *cobra.Command, scanResponseModel *wrappers.ScanResponseModel,
 ) *wrappers.ScanResponseModel {
@@ -1183,16 +1371,15 @@ func enrichScanResponseModel(
 
 func createScanModel(
 	cmd *cobra.Command,
+	scanTypes, branch string,
 	uploadsWrapper wrappers.UploadsWrapper,
 	projectsWrapper wrappers.ProjectsWrapper,
 	groupsWrapper wrappers.GroupsWrapper,
 ) (*wrappers.Scan, string, error) {
-	validateScanTypes(cmd)
-
 	var input = []byte("{}")
 
 	// Define type, project and config in scan model
-	err := setupScanTypeProjectAndConfig(&input, cmd, projectsWrapper, groupsWrapper)
+	err := setupScanTypeProjectAndConfig(&input, cmd, scanTypes, projectsWrapper, groupsWrapper)
 	if err != nil {
 		return nil, "", err
 	}
@@ -1208,7 +1395,7 @@ func createScanModel(
 	}
 
 	// Set up the scan handler (either git or upload)
-	scanHandler, zipFilePath, err := setupScanHandler(cmd, uploadsWrapper)
+	scanHandler, zipFilePath, err := setupScanHandler(cmd, scanTypes, branch, uploadsWrapper)
 	if err != nil {
 		return nil, zipFilePath, err
 	}
@@ -1218,12 +1405,21 @@ func createScanModel(
 	uploadType := getUploadType(cmd)
 
 	if uploadType == "git" {
-		log.Printf("\n\nScanning branch %s...\n", viper.GetString(commonParams.BranchKey))
+		log.Printf("\n\nScanning branch %s...\n", branch)
 	}
 
 	return &scanModel, zipFilePath, nil
 }
 
+// getScanBranch returns the branch given to the command, falling back to the environment when the flag is not set
+func getScanBranch(cmd *cobra.Command) string {
+	if cmd.Flags().Changed(commonParams.BranchFlag) {
+		branch, _ := cmd.Flags().GetString(commonParams.BranchFlag)
+		return branch
+	}
+	return viper.GetString(commonParams.BranchKey)
+}
+
 func getUploadType(cmd *cobra.Command) string {
 	source, _ := cmd.Flags().GetString(commonParams.SourcesFlag)
 	sourceTrimmed := strings.TrimSpace(source)
@@ -1235,14 +1431,14 @@ func getUploadType(cmd *cobra.Command) string {
 	return "upload"
 }
 
-func setupScanHandler(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper) (
+func setupScanHandler(cmd *cobra.Command, scanTypes, branch string, uploadsWrapper wrappers.UploadsWrapper) (
 	wrappers.ScanHandler,
 	string,
 	error,
 ) {
 	zipFilePath := ""
 	scanHandler := wrappers.ScanHandler{}
-	scanHandler.Branch = viper.GetString(commonParams.BranchKey)
+	scanHandler.Branch = branch
 
 	uploadType := getUploadType(cmd)
 
@@ -1253,7 +1449,7 @@ func setupScanHandler(cmd *cobra.Command, uploadsWrapper wrappers.UploadsWrapper
 	} else {
 		var err error
 		var uploadURL string
-		uploadURL, zipFilePath, err = getUploadURLFromSource(cmd, uploadsWrapper)
+		uploadURL, zipFilePath, err = getUploadURLFromSource(cmd, scanTypes, uploadsWrapper)
 		if err != nil {
 			return scanHandler, zipFilePath, err
 		}
@@ -1308,8 +1504,9 @@ func handleWait(
 	timeoutMinutes int,
 	scansWrapper wrappers.ScansWrapper,
 	resultsWrapper wrappers.ResultsWrapper,
+	hooks *scanHooks,
 ) error {
-	err := waitForScanCompletion(scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper, cmd)
+	err := waitForScanCompletion(scanResponseModel, waitDelay, timeoutMinutes, scansWrapper, resultsWrapper, cmd, hooks)
 	if err != nil {
 		verboseFlag, _ := cmd.Flags().GetBool(commonParams.DebugFlag)
 		if verboseFlag {
@@ -1327,6 +1524,7 @@ func createReportsAfterScan(
 	scanID string,
 	scansWrapper wrappers.ScansWrapper,
 	resultsWrapper wrappers.ResultsWrapper,
+	enrichers ...resultsEnricher,
 ) error {
 	// Create the required reports
 	targetFile, _ := cmd.Flags().GetString(commonParams.TargetFlag)
@@ -1347,6 +1545,7 @@ func createReportsAfterScan(
 		targetFile,
 		targetPath,
 		params,
+		enrichers...,
 	)
 }
 
@@ -1354,6 +1553,7 @@ func applyThreshold(
 	cmd *cobra.Command,
 	resultsWrapper wrappers.ResultsWrapper,
 	scanResponseModel *wrappers.ScanResponseModel,
+	hooks *scanHooks,
 ) error {
 	threshold, _ := cmd.Flags().GetString(commonParams.Threshold)
 	if strings.TrimSpace(threshold) == "" {
@@ -1384,6 +1584,7 @@ func applyThreshold(
 
 	errorMessage := errorBuilder.String()
 	if errorMessage != "" {
+		hooks.fire(wrappers.HookScanThresholdFailed, scanResponseModel, errorMessage)
 		return errors.Errorf(thresholdMsgLog, "Failed", errorMessage)
 	}
 
@@ -1432,12 +1633,16 @@ func waitForScanCompletion(
 	scansWrapper wrappers.ScansWrapper,
 	resultsWrapper wrappers.ResultsWrapper,
 	cmd *cobra.Command,
+	hooks *scanHooks,
 ) error {
 	log.Println("Wait for scan to complete", scanResponseModel.ID, scanResponseModel.Status)
+	noProgress, _ := cmd.Flags().GetBool(commonParams.NoProgressFlag)
+	renderer := progress.NewRenderer(cmd.OutOrStdout(), !noProgress)
+	defer renderer.Done()
 	timeout := time.Now().Add(time.Duration(timeoutMinutes) * time.Minute)
 	time.Sleep(time.Duration(waitDelay) * time.Second)
 	for {
-		running, err := isScanRunning(scansWrapper, resultsWrapper, scanResponseModel.ID, cmd)
+		running, err := isScanRunning(scansWrapper, resultsWrapper, scanResponseModel.ID, cmd, renderer, hooks)
 		if err != nil {
 			return err
 		}
@@ -1445,6 +1650,7 @@ func waitForScanCompletion(
 			break
 		}
 		if timeoutMinutes > 0 && time.Now().After(timeout) {
+			renderer.Done()
 			log.Println("Canceling scan", scanResponseModel.ID)
 			errorModel, err := scansWrapper.Cancel(scanResponseModel.ID)
 			if err != nil {
@@ -1453,7 +1659,9 @@ func waitForScanCompletion(
 			if errorModel != nil {
 				return errors.Errorf(ErrorCodeFormat, failedCanceling, errorModel.Code, errorModel.Message)
 			}
-			return errors.Errorf("Timeout of %d minute(s) for scan reached", timeoutMinutes)
+			timeoutErr := errors.Errorf("Timeout of %d minute(s) for scan reached", timeoutMinutes)
+			hooks.fire(wrappers.HookScanFailed, scanResponseModel, timeoutErr.Error())
+			return timeoutErr
 		}
 		time.Sleep(time.Duration(waitDelay) * time.Second)
 	}
@@ -1461,7 +1669,12 @@ func waitForScanCompletion(
 }
 
 func isScanRunning(
-	scansWrapper wrappers.ScansWrapper, resultsWrapper wrappers.ResultsWrapper, scanID string, cmd *cobra.Command,
+	scansWrapper wrappers.ScansWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	scanID string,
+	cmd *cobra.Command,
+	renderer progress.Renderer,
+	hooks *scanHooks,
 ) (bool, error) {
 	var scanResponseModel *wrappers.ScanResponseModel
 	var errorModel *wrappers.ErrorModel
@@ -1474,11 +1687,13 @@ func isScanRunning(
 		log.Fatal(fmt.Sprintf("%s: CODE: %d, %s", failedGetting, errorModel.Code, errorModel.Message))
 	} else if scanResponseModel != nil {
 		if scanResponseModel.Status == wrappers.ScanRunning || scanResponseModel.Status == wrappers.ScanQueued {
-			log.Println("Scan status: ", scanResponseModel.Status)
+			renderer.Update(scanResponseModel)
 			return true, nil
 		}
 	}
+	renderer.Done()
 	log.Println("Scan Finished with status: ", scanResponseModel.Status)
+	hooks.fire(scanStatusHookEvent(scanResponseModel.Status), scanResponseModel, "")
 	if scanResponseModel.Status == wrappers.ScanPartial {
 		_ = printer.Print(cmd.OutOrStdout(), scanResponseModel.StatusDetails, printer.FormatList)
 		reportErr := createReportsAfterScan(cmd, scanResponseModel.ID, scansWrapper, resultsWrapper)
@@ -1500,8 +1715,16 @@ func runListScansCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Com
 		if err != nil {
 			return errors.Wrapf(err, "%s", failedGettingAll)
 		}
+		all, pageSize, concurrency, err := getAllPagesFlags(cmd, params)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedGettingAll)
+		}
+		listWrapper := scansWrapper
+		if all {
+			listWrapper = wrappers.NewAllPagesScansWrapper(scansWrapper, pageSize, concurrency)
+		}
 
-		allScansModel, errorModel, err = scansWrapper.Get(params)
+		allScansModel, errorModel, err = listWrapper.Get(params)
 		if err != nil {
 			return errors.Wrapf(err, "%s\n", failedGettingAll)
 		}
@@ -1509,6 +1732,7 @@ func runListScansCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Com
 		if errorModel != nil {
 			return errors.Errorf(ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
 		} else if allScansModel != nil && allScansModel.Scans != nil {
+			warnTruncated("scans", params, len(allScansModel.Scans), allScansModel.FilteredTotalCount)
 			err = printByFormat(cmd, toScanViews(allScansModel.Scans))
 			if err != nil {
 				return err
@@ -1518,6 +1742,35 @@ func runListScansCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Com
 	}
 }
 
+// getLatestScan returns the most recent completed scan of the project, or nil when there is none
+func getLatestScan(scansWrapper wrappers.ScansWrapper, projectID string) (*wrappers.ScanResponseModel, error) {
+	return getLatestBranchScan(scansWrapper, projectID, "")
+}
+
+// getLatestBranchScan returns the latest completed scan of the branch, of any branch when it is empty
+func getLatestBranchScan(scansWrapper wrappers.ScansWrapper, projectID, branch string) (*wrappers.ScanResponseModel, error) {
+	params := map[string]string{
+		commonParams.ProjectIDQueryParam: projectID,
+		commonParams.StatusesQueryParam:  string(wrappers.ScanCompleted),
+		commonParams.LimitQueryParam:     "1",
+		commonParams.SortQueryParam:      latestScanSort,
+	}
+	if branch != "" {
+		params[commonParams.BranchQueryParam] = branch
+	}
+	scans, errorModel, err := scansWrapper.Get(params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingAll)
+	}
+	if errorModel != nil {
+		return nil, errors.Errorf(ErrorCodeFormat, failedGettingAll, errorModel.Code, errorModel.Message)
+	}
+	if scans == nil || len(scans.Scans) == 0 {
+		return nil, nil
+	}
+	return &scans.Scans[0], nil
+}
+
 func runGetScanByIDCommand(scansWrapper wrappers.ScansWrapper) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		var scanResponseModel *wrappers.ScanResponseModel
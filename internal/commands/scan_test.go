@@ -3,12 +3,22 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
 
 	"github.com/checkmarx/ast-cli/internal/commands/util"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
 	"github.com/spf13/viper"
 )
 
@@ -104,6 +114,15 @@ func TestRunGetAllCommandStatusesList(t *testing.T) {
 	)
 }
 
+func TestRunGetAllCommandAllPages(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "list", "--all", "--concurrency", "2", "--filter", "statuses=Completed,limit=50")
+}
+
+func TestRunGetAllCommandAllPagesWithOffset(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "list", "--all", "--filter", "offset=100")
+	assert.Equal(t, err.Error(), "Failed listing: The offset filter can't be used with --all")
+}
+
 func TestRunGetAllCommandFlagNonExist(t *testing.T) {
 	err := execCmdNotNilAssertion(t, "scan", "list", "--chibutero")
 	assert.Assert(t, err.Error() == unknownFlag)
@@ -385,3 +404,466 @@ func TestCreateRealtimeKicsFailedScan(t *testing.T) {
 	err := executeTestCommand(cmd, baseArgs...)
 	assert.NilError(t, err)
 }
+
+func TestCreateScanWithScanConfigFile(t *testing.T) {
+	// Project name, branch and threshold come from the configuration file
+	err := execCmdNotNilAssertion(t, "scan", "create", "-s", "data/scan-config")
+	assert.Assert(t, strings.Contains(err.Error(), "Threshold check finished with status Failed"), err.Error())
+}
+
+func TestCreateScanWithScanConfigFileOverriddenByFlags(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "create", "-s", "data/scan-config", "--threshold", "sast-high=10")
+}
+
+func TestCreateScanWithInvalidScanConfigFile(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "create", "-s", "data/scan-config-invalid")
+	assert.Assert(t, strings.Contains(err.Error(), failedLoadingScanConfig), err.Error())
+}
+
+func TestCreateScanWithManifest(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "create", "--manifest", "data/manifest/services.yaml", "-b", "dummy_branch")
+}
+
+func TestCreateScanWithManifestAsync(t *testing.T) {
+	execCmdNilAssertion(
+		t,
+		"scan", "create",
+		"--manifest", "data/manifest/services.yaml",
+		"-b", "dummy_branch",
+		"--manifest-parallelism", "1",
+		"--async",
+	)
+}
+
+func TestCreateScanWithInvalidManifest(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "create", "--manifest", "data/manifest/invalid.yaml", "-b", "dummy_branch")
+	assert.Assert(t, strings.Contains(err.Error(), "unknown scan type for svc-a: dast"), err.Error())
+	assert.Assert(t, strings.Contains(err.Error(), "is not a directory"), err.Error())
+}
+
+func TestCreateScanWithManifestAndProjectName(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"scan", "create",
+		"--manifest", "data/manifest/services.yaml",
+		"--project-name", "MOCK",
+	)
+	assert.Assert(t, strings.Contains(err.Error(), "--manifest cannot be combined"), err.Error())
+}
+
+func TestCreateScanMissingProjectName(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "create", "-s", dummyRepo, "-b", "dummy_branch")
+	assert.Equal(t, err.Error(), "required flag(s) \"project-name\" not set")
+}
+
+func TestScanWatch(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "watch", "--scan-id", "MOCK", "--wait-delay", "0")
+}
+
+func TestScanWatchWithThreshold(t *testing.T) {
+	err := execCmdNotNilAssertion(
+		t,
+		"scan", "watch",
+		"--scan-id", "MOCK",
+		"--wait-delay", "0",
+		"--threshold", "sast-high=1",
+	)
+	assert.Assert(t, strings.Contains(err.Error(), "Threshold check finished with status Failed"), err.Error())
+}
+
+func TestScanWatchNoScanID(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "watch")
+	assert.Equal(t, err.Error(), "Failed watching a scan: Please provide a scan ID")
+}
+
+func TestScanWatchInvalidTimeout(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "watch", "--scan-id", "MOCK", "--scan-timeout", "-1")
+	assert.Equal(t, err.Error(), "--scan-timeout should be equal or higher than 0")
+}
+
+func TestScanWatchNoProgress(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "watch", "--scan-id", "MOCK", "--wait-delay", "0", "--no-progress")
+}
+
+// hookEvents returns the target and event of every hook delivery, checking the payload matches its event
+func hookEvents(t *testing.T, hooks *mock.HooksMockWrapper) []string {
+	assert.Equal(t, hooks.Calls, len(hooks.Deliveries))
+	var events []string
+	for _, delivery := range hooks.Deliveries {
+		payload := wrappers.HookPayload{}
+		assert.NilError(t, json.Unmarshal(delivery.Body, &payload))
+		assert.Assert(t, payload.Scan != nil && payload.Scan.ID != "", string(delivery.Body))
+		if delivery.Headers != nil {
+			assert.Equal(t, delivery.Headers[hookEventHeader], payload.Event)
+		} else {
+			assert.Equal(t, delivery.Env[0], hookEventEnv+"="+payload.Event)
+		}
+		events = append(events, delivery.Target+" "+payload.Event)
+	}
+	return events
+}
+
+func TestCreateScanWithHooks(t *testing.T) {
+	hooks := &mock.HooksMockWrapper{}
+	cmd := createASTTestCommandWithMocks(&mock.ScansMockWrapper{Running: true}, hooks)
+	err := executeTestCommand(
+		cmd,
+		"scan", "create", "--project-name", "MOCK", "-s", dummyRepo, "-b", "dummy_branch", "--wait-delay", "0",
+		"--hook-url", "http://hooks.example.com", "--hook-command", "notify", "--hook-secret", "s",
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(
+		t, hookEvents(t, hooks), []string{
+			"http://hooks.example.com scan.created",
+			"notify scan.created",
+			"http://hooks.example.com scan.completed",
+			"notify scan.completed",
+		},
+	)
+	created, completed := hooks.Deliveries[0], hooks.Deliveries[2]
+	assert.Equal(t, created.Headers[hookSignatureHeader], signHookPayload("s", created.Body))
+	assert.Equal(t, hooks.Deliveries[1].Env[1], hookSignatureEnv+"="+signHookPayload("s", hooks.Deliveries[1].Body))
+	payload := wrappers.HookPayload{}
+	assert.NilError(t, json.Unmarshal(completed.Body, &payload))
+	assert.Equal(t, string(payload.Scan.Status), wrappers.ScanCompleted)
+	assert.Assert(t, payload.Summary != nil, "The completion payload should have the results summary")
+}
+
+func TestCreateScanWithFailingHook(t *testing.T) {
+	hooks := &mock.HooksMockWrapper{}
+	cmd := createASTTestCommandWithMocks(&mock.ScansMockWrapper{Running: true}, hooks)
+	err := executeTestCommand(
+		cmd,
+		"scan", "create", "--project-name", "MOCK", "-s", dummyRepo, "-b", "dummy_branch", "--wait-delay", "0",
+		"--hook-url", "http://fail.example.com", "--hook-retries", "1", "--hook-events", "scan.created",
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, hookEvents(t, hooks), []string{"http://fail.example.com scan.created", "http://fail.example.com scan.created"})
+}
+
+func TestCreateScanRejectedFiresNoHooks(t *testing.T) {
+	hooks := &mock.HooksMockWrapper{}
+	cmd := createASTTestCommandWithMocks(&mock.ScansMockWrapper{CreateRejected: true}, hooks)
+	err := executeTestCommand(
+		cmd,
+		"scan", "create", "--project-name", "MOCK", "-s", dummyRepo, "-b", "dummy_branch", "--hook-command", "notify",
+	)
+	assert.ErrorContains(t, err, "Failed creating a scan: CODE: 400, MOCK-REJECTED")
+	assert.Equal(t, hooks.Calls, 0)
+}
+
+func TestCreateScanWithInvalidHookEvent(t *testing.T) {
+	baseArgs := []string{"scan", "create", "--project-name", "MOCK", "-s", dummyRepo, "-b", "dummy_branch"}
+	err := execCmdNotNilAssertion(
+		t,
+		append(baseArgs, "--hook-url", "http://hooks.example.com", "--hook-events", "scan.started")...,
+	)
+	assert.Equal(t, err.Error(), "unknown hook event: scan.started")
+}
+
+// finishedScansMockWrapper returns scans that already completed
+type finishedScansMockWrapper struct {
+	mock.ScansMockWrapper
+}
+
+func (m *finishedScansMockWrapper) GetByID(scanID string) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
+	return &wrappers.ScanResponseModel{ID: scanID, ProjectID: "MOCK", Status: wrappers.ScanCompleted}, nil, nil
+}
+
+func TestScanWatchWithHooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		scans    wrappers.ScansWrapper
+		expected []string
+	}{
+		{name: "running scan", scans: &mock.ScansMockWrapper{Running: true}, expected: []string{"notify scan.completed"}},
+		{name: "finished scan", scans: &finishedScansMockWrapper{}, expected: nil},
+	}
+	for _, tt := range tests {
+		hooks := &mock.HooksMockWrapper{}
+		cmd := createASTTestCommandWithMocks(tt.scans, hooks)
+		err := executeTestCommand(
+			cmd,
+			"scan", "watch",
+			"--scan-id", "MOCK",
+			"--wait-delay", "0",
+			"--hook-command", "notify",
+			"--hook-events", "scan.completed,scan.threshold_failed",
+		)
+		assert.NilError(t, err, tt.name)
+		assert.DeepEqual(t, hookEvents(t, hooks), tt.expected)
+	}
+}
+
+func TestSignHookPayload(t *testing.T) {
+	signature := signHookPayload("secret", []byte(`{"event":"scan.completed"}`))
+	assert.Assert(t, strings.HasPrefix(signature, "sha256="), signature)
+	assert.Equal(t, signature, signHookPayload("secret", []byte(`{"event":"scan.completed"}`)))
+	assert.Assert(t, signature != signHookPayload("other", []byte(`{"event":"scan.completed"}`)))
+}
+
+// listingScansMockWrapper serves its scans like the API: filtered by the query params, newest first and paged
+type listingScansMockWrapper struct {
+	mock.ScansMockWrapper
+	scans   []wrappers.ScanResponseModel
+	params  []map[string]string
+	deleted []string
+}
+
+func (m *listingScansMockWrapper) Get(params map[string]string) (*wrappers.ScansCollectionResponseModel, *wrappers.ErrorModel, error) {
+	m.params = append(m.params, params)
+	fromDate, _ := time.Parse(time.RFC3339, params[commonParams.FromDateQueryParam])
+	toDate, _ := time.Parse(time.RFC3339, params[commonParams.ToDateQueryParam])
+	var scans []wrappers.ScanResponseModel
+	for _, scan := range m.scans {
+		if (params[commonParams.ProjectIDQueryParam] != "" && scan.ProjectID != params[commonParams.ProjectIDQueryParam]) ||
+			(params[commonParams.BranchQueryParam] != "" && scan.Branch != params[commonParams.BranchQueryParam]) ||
+			(params[commonParams.StatusesQueryParam] != "" && !strings.Contains(params[commonParams.StatusesQueryParam], string(scan.Status))) ||
+			(!fromDate.IsZero() && scan.CreatedAt.Before(fromDate)) ||
+			(!toDate.IsZero() && scan.CreatedAt.After(toDate)) {
+			continue
+		}
+		scans = append(scans, scan)
+	}
+	sort.SliceStable(scans, func(i, j int) bool { return scans[i].CreatedAt.After(scans[j].CreatedAt) })
+	offset, _ := strconv.Atoi(params[commonParams.OffsetQueryParam])
+	limit, _ := strconv.Atoi(params[commonParams.LimitQueryParam])
+	if offset > len(scans) {
+		offset = len(scans)
+	}
+	end := len(scans)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return &wrappers.ScansCollectionResponseModel{Scans: scans[offset:end], FilteredTotalCount: uint(len(scans))}, nil, nil
+}
+
+func (m *listingScansMockWrapper) Delete(scanID string) (*wrappers.ErrorModel, error) {
+	m.deleted = append(m.deleted, scanID)
+	return nil, nil
+}
+
+// mainBranchProjectsMockWrapper returns the projects with their main branch, if any
+type mainBranchProjectsMockWrapper struct {
+	mock.ProjectsMockWrapper
+	mainBranches map[string]string
+}
+
+func (m *mainBranchProjectsMockWrapper) GetByID(projectID string) (*wrappers.ProjectResponseModel, *wrappers.ErrorModel, error) {
+	return &wrappers.ProjectResponseModel{ID: projectID, MainBranch: m.mainBranches[projectID]}, nil, nil
+}
+
+func newPruneTestScans() *listingScansMockWrapper {
+	daysAgo := func(days int) time.Time {
+		return time.Now().AddDate(0, 0, -days)
+	}
+	return &listingScansMockWrapper{
+		scans: []wrappers.ScanResponseModel{
+			{ID: "dev-1", ProjectID: "A", Branch: "dev", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(0)},
+			{ID: "main-1", ProjectID: "A", Branch: "main", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(40)},
+			{ID: "main-2", ProjectID: "A", Branch: "main", Status: wrappers.ScanFailed, CreatedAt: daysAgo(50)},
+			{ID: "main-3", ProjectID: "A", Branch: "main", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(100)},
+			{ID: "b-1", ProjectID: "B", Branch: "feature", Status: wrappers.ScanFailed, CreatedAt: daysAgo(35)},
+			{ID: "b-2", ProjectID: "B", Branch: "feature", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(60)},
+		},
+	}
+}
+
+func runScanPrune(scans *listingScansMockWrapper, args ...string) ([]scanPruneView, string, error) {
+	projects := &mainBranchProjectsMockWrapper{mainBranches: map[string]string{"A": "main"}}
+	cmd := scanPruneSubCommand(scans, projects)
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	var views []scanPruneView
+	// JSON output is a single document, table output is left undecoded
+	_ = json.Unmarshal(output.Bytes(), &views)
+	return views, output.String(), err
+}
+
+func TestScanPruneOlderThan(t *testing.T) {
+	scans := newPruneTestScans()
+	views, _, err := runScanPrune(scans, "--older-than", "30", "--yes", "--format", "json")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, scans.deleted, []string{"b-1", "main-2", "main-3"})
+	for _, view := range views {
+		assert.Equal(t, view.Result, scanPruneDeleted, view.ScanID)
+	}
+	assert.Equal(t, len(views), 3)
+	assert.Assert(t, scans.params[0][commonParams.ToDateQueryParam] != "", "The listing should be limited to the old scans")
+}
+
+func TestScanPruneSendsTheRulesToTheListing(t *testing.T) {
+	now := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		projectID string
+		rules     scanPruneRules
+		expected  map[string]string
+	}{
+		{
+			name:      "all the filters",
+			projectID: "A",
+			rules:     scanPruneRules{OlderThan: 30, NewerThan: 90, Statuses: []string{"Failed", "Canceled"}},
+			expected: map[string]string{
+				commonParams.ProjectIDQueryParam: "A",
+				commonParams.StatusesQueryParam:  "Failed,Canceled",
+				commonParams.FromDateQueryParam:  "2022-04-01T00:00:00Z",
+				commonParams.ToDateQueryParam:    "2022-05-31T00:00:00Z",
+			},
+		},
+		{
+			name:     "keep last needs the newer scans",
+			rules:    scanPruneRules{KeepLast: 3, OlderThan: 30, NewerThan: 90},
+			expected: map[string]string{commonParams.FromDateQueryParam: "2022-04-01T00:00:00Z"},
+		},
+		{
+			name:     "keep last ranks every status",
+			rules:    scanPruneRules{KeepLast: 1, Statuses: []string{"Failed"}},
+			expected: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		assert.DeepEqual(t, scanPruneParams(tt.projectID, tt.rules, now), tt.expected)
+	}
+}
+
+func TestScanPruneKeepLastWithStatuses(t *testing.T) {
+	scans := newPruneTestScans()
+	views, _, err := runScanPrune(scans, "--keep-last", "1", "--statuses", "Failed", "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	// b-1 is the latest scan of its branch, main-2 is behind the completed main-1
+	assert.Equal(t, len(views), 1)
+	assert.Equal(t, views[0].ScanID, "main-2")
+}
+
+func TestScanPruneConfirmation(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		answer        string
+		expected      []string
+		planInOutput  bool
+		promptOnError bool
+	}{
+		{name: "table aborted", format: printer.FormatTable, answer: "n\n", planInOutput: true},
+		{name: "table confirmed", format: printer.FormatTable, answer: "y\n", expected: []string{"main-2"}, planInOutput: true},
+		{name: "json confirmed", format: printer.FormatJSON, answer: "y\n", expected: []string{"main-2"}, promptOnError: true},
+	}
+	for _, tt := range tests {
+		scans := newPruneTestScans()
+		projects := &mainBranchProjectsMockWrapper{mainBranches: map[string]string{"A": "main"}}
+		cmd := scanPruneSubCommand(scans, projects)
+		var output, errOutput bytes.Buffer
+		cmd.SetOut(&output)
+		cmd.SetErr(&errOutput)
+		cmd.SetIn(strings.NewReader(tt.answer))
+		cmd.SetArgs([]string{"--statuses", "Failed", "--project-id", "A", "--format", tt.format})
+		assert.NilError(t, cmd.Execute(), tt.name)
+		assert.DeepEqual(t, scans.deleted, tt.expected)
+		prompt := fmt.Sprintf(scanPruneConfirm, 1)
+		assert.Equal(t, strings.Contains(errOutput.String(), prompt), tt.promptOnError, tt.name)
+		if tt.planInOutput {
+			assert.Assert(t, strings.Index(output.String(), scanPrunePlanned) < strings.Index(output.String(), prompt), tt.name)
+			continue
+		}
+		var views []scanPruneView
+		assert.NilError(t, json.Unmarshal(output.Bytes(), &views), tt.name)
+		assert.Equal(t, len(views), 1, tt.name)
+		assert.Equal(t, views[0].Result, scanPruneDeleted, tt.name)
+	}
+}
+
+func TestScanPruneNewerThan(t *testing.T) {
+	scans := newPruneTestScans()
+	views, _, err := runScanPrune(scans, "--older-than", "30", "--newer-than", "55", "--dry-run", "--format", "json")
+	assert.NilError(t, err)
+	var planned []string
+	for _, view := range views {
+		planned = append(planned, view.ScanID)
+	}
+	assert.DeepEqual(t, planned, []string{"b-1", "main-2"})
+	assert.Equal(t, len(scans.deleted), 0)
+
+	_, _, err = runScanPrune(scans, "--older-than", "30", "--newer-than", "30")
+	assert.ErrorContains(t, err, "--newer-than must be higher than --older-than")
+}
+
+func TestScanPruneDryRun(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "prune", "--project-id", "MOCK", "--keep-last", "5", "--older-than", "30", "--dry-run")
+}
+
+func TestScanPruneNothingToDelete(t *testing.T) {
+	execCmdNilAssertion(t, "scan", "prune", "--statuses", "Failed,Canceled")
+
+	scans := newPruneTestScans()
+	views, output, err := runScanPrune(scans, "--statuses", "Canceled", "--format", "json")
+	assert.NilError(t, err)
+	assert.Equal(t, len(views), 0)
+	assert.Equal(t, strings.TrimSpace(output), "[]")
+}
+
+func TestGetProtectedScans(t *testing.T) {
+	scans := newPruneTestScans()
+	projects := &mainBranchProjectsMockWrapper{mainBranches: map[string]string{"A": "main"}}
+	protected, err := getProtectedScans(scans, projects, scans.scans)
+	assert.NilError(t, err)
+	// The main branch of A, and the latest completed scan of B that has no main branch
+	assert.DeepEqual(t, protected, map[string]bool{"main-1": true, "b-2": true})
+	assert.Equal(t, len(scans.params), 2, "The projects should be looked up once each")
+}
+
+func TestScanPruneWithNoRules(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "prune")
+	assert.Equal(t, err.Error(), "Failed pruning scans: Please provide at least one of --keep-last, --older-than or --statuses")
+}
+
+func TestScanPruneInvalidKeepLast(t *testing.T) {
+	err := execCmdNotNilAssertion(t, "scan", "prune", "--keep-last", "0")
+	assert.Equal(t, err.Error(), "Failed pruning scans: --keep-last must be at least 1")
+}
+
+func TestPlanScanPrune(t *testing.T) {
+	now := time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	scans := []wrappers.ScanResponseModel{
+		{ID: "main-1", ProjectID: "A", Branch: "main", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(1)},
+		{ID: "main-2", ProjectID: "A", Branch: "main", Status: wrappers.ScanFailed, CreatedAt: daysAgo(50)},
+		{ID: "main-3", ProjectID: "A", Branch: "main", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(100)},
+		{ID: "dev-1", ProjectID: "A", Branch: "dev", Status: wrappers.ScanCanceled, CreatedAt: daysAgo(60)},
+		{ID: "other-1", ProjectID: "B", Branch: "main", Status: wrappers.ScanCompleted, CreatedAt: daysAgo(200)},
+	}
+	protected := map[string]bool{"main-1": true, "other-1": true}
+
+	ids := func(views []scanPruneView) []string {
+		var scanIDs []string
+		for _, view := range views {
+			scanIDs = append(scanIDs, view.ScanID)
+		}
+		return scanIDs
+	}
+	assert.DeepEqual(t, ids(planScanPrune(scans, protected, scanPruneRules{KeepLast: 1}, now)), []string{"main-2", "main-3"})
+	assert.DeepEqual(t, ids(planScanPrune(scans, protected, scanPruneRules{OlderThan: 55}, now)), []string{"dev-1", "main-3"})
+	assert.DeepEqual(
+		t,
+		ids(planScanPrune(scans, protected, scanPruneRules{Statuses: []string{"failed", "canceled"}}, now)),
+		[]string{"main-2", "dev-1"},
+	)
+	views := planScanPrune(scans, protected, scanPruneRules{KeepLast: 1, OlderThan: 30, Statuses: []string{"Failed"}}, now)
+	assert.DeepEqual(t, ids(views), []string{"main-2"})
+	assert.Equal(t, views[0].Reason, "not in the last 1 scans, older than 30 days, status Failed")
+	assert.Assert(t, planScanPrune(scans, protected, scanPruneRules{OlderThan: 300}, now) == nil)
+}
+
+func TestConfirmScanPrune(t *testing.T) {
+	out := &bytes.Buffer{}
+	assert.Assert(t, confirmScanPrune(strings.NewReader("y\n"), out, 3))
+	assert.Equal(t, out.String(), "Delete 3 scans? (y/N) ")
+	assert.Assert(t, confirmScanPrune(strings.NewReader("Y"), out, 3))
+	assert.Assert(t, !confirmScanPrune(strings.NewReader("yes\n"), out, 3))
+	assert.Assert(t, !confirmScanPrune(strings.NewReader("\n"), out, 3))
+	assert.Assert(t, !confirmScanPrune(strings.NewReader(""), out, 3))
+}
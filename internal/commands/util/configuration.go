@@ -1,10 +1,12 @@
 package util
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
 	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers/configuration"
 	"github.com/pkg/errors"
@@ -12,22 +14,40 @@ import (
 )
 
 const (
-	failedSettingProp = "Failed to set property"
-	propNameFlag      = "prop-name"
-	propValFlag       = "prop-value"
+	failedSettingProp    = "Failed to set property"
+	failedValidatingFile = "Failed validating the scan configuration file"
+	propNameFlag         = "prop-name"
+	propValFlag          = "prop-value"
+	configFileFlag       = "file"
 )
 
+var scanConfigReportFormats = []string{
+	printer.FormatJSON,
+	printer.FormatSarif,
+	printer.FormatSonar,
+	printer.FormatSummary,
+	printer.FormatSummaryJSON,
+	printer.FormatSummaryConsole,
+}
+
 var Properties = map[string]bool{
 	params.BaseURIKey:               true,
 	params.BaseAuthURIKey:           true,
 	params.TenantKey:                true,
 	params.ProxyKey:                 true,
 	params.ProxyTypeKey:             true,
+	params.HTTPSProxyKey:            true,
+	params.NoProxyKey:               true,
+	params.ProxyRulesKey:            true,
 	params.AccessKeyIDConfigKey:     true,
 	params.AccessKeySecretConfigKey: true,
 	params.AstAPIKey:                true,
 	params.BranchKey:                true,
 	params.ClientTimeoutKey:         true,
+	params.CACertKey:                true,
+	params.ClientCertKey:            true,
+	params.ClientKeyKey:             true,
+	params.MinTLSVersionKey:         true,
 }
 
 func NewConfigCommand() *cobra.Command {
@@ -110,7 +130,32 @@ func NewConfigCommand() *cobra.Command {
 	setCmd.PersistentFlags().String(propNameFlag, "", "Name of property set")
 	setCmd.PersistentFlags().String(propValFlag, "", "Value of property set")
 
-	configureCmd.AddCommand(showCmd, setCmd)
+	validateFileCmd := &cobra.Command{
+		Use:   "validate-file",
+		Short: "Validate a scan configuration file",
+		Long: "Validate the schema and values of a project-local scan configuration file (.checkmarx.yaml) " +
+			"used by 'cx scan create'",
+		RunE: runValidateScanConfigFile(),
+		Example: heredoc.Doc(
+			`
+			$ cx configure validate-file --file .checkmarx.yaml
+			Scan configuration file .checkmarx.yaml is valid`,
+		),
+		Annotations: map[string]string{
+			"command:doc": heredoc.Doc(
+				`
+				https://checkmarx.atlassian.net/wiki/x/gwQRtw
+			`,
+			),
+		},
+	}
+	validateFileCmd.PersistentFlags().String(
+		configFileFlag,
+		configuration.ScanConfigFileNames[0],
+		"Path of the scan configuration file",
+	)
+
+	configureCmd.AddCommand(showCmd, setCmd, validateFileCmd)
 	return configureCmd
 }
 
@@ -126,3 +171,29 @@ func runSetValue() func(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 }
+
+func runValidateScanConfigFile() func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		configPath, _ := cmd.Flags().GetString(configFileFlag)
+		scanConfig, err := configuration.LoadScanConfig(configPath)
+		if err != nil {
+			return errors.Wrapf(err, "%s", failedValidatingFile)
+		}
+		for _, format := range scanConfig.Report.Formats {
+			if !isScanConfigReportFormat(format) {
+				return errors.Errorf("%s: unknown report format %s", failedValidatingFile, format)
+			}
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Scan configuration file %s is valid\n", configPath)
+		return nil
+	}
+}
+
+func isScanConfigReportFormat(format string) bool {
+	for _, reportFormat := range scanConfigReportFormats {
+		if printer.IsFormat(strings.TrimSpace(format), reportFormat) {
+			return true
+		}
+	}
+	return false
+}
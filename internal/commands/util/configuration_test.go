@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"gotest.tools/assert"
@@ -23,3 +24,22 @@ func TestNewConfigCommand(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Assert(t, err.Error() == "Failed to set property: unknown property or bad value")
 }
+
+func TestValidateScanConfigFile(t *testing.T) {
+	cmd := NewConfigCommand()
+	err := executeTestCommand(cmd, "validate-file", "--file", "../data/scan-config/.checkmarx.yaml")
+	assert.NilError(t, err)
+}
+
+func TestValidateScanConfigFileInvalid(t *testing.T) {
+	cmd := NewConfigCommand()
+	err := executeTestCommand(cmd, "validate-file", "--file", "../data/scan-config-invalid/.checkmarx.yaml")
+	assert.Assert(t, err != nil)
+	assert.Assert(t, strings.Contains(err.Error(), "field unknownKey not found"), err.Error())
+}
+
+func TestValidateScanConfigFileMissing(t *testing.T) {
+	cmd := NewConfigCommand()
+	err := executeTestCommand(cmd, "validate-file", "--file", "../data/missing.yaml")
+	assert.Assert(t, err != nil)
+}
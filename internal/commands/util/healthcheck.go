@@ -0,0 +1,126 @@
+package util
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	healthCheckSuccess = "Success"
+	healthCheckFailure = "Failure"
+)
+
+type healthCheck struct {
+	name string
+	run  func() error
+}
+
+type healthCheckView struct {
+	Name      string `json:"name" format:"name:Name"`
+	Status    string `json:"status" format:"name:Status"`
+	LatencyMs int64  `json:"latencyMs" format:"name:Latency (ms)"`
+	Details   string `json:"details" format:"name:Details"`
+}
+
+func NewHealthCheckCommand(healthCheckWrapper wrappers.HealthCheckWrapper) *cobra.Command {
+	healthCheckCmd := &cobra.Command{
+		Use:   "health-check",
+		Short: "Run the health checks of a CxAST installation",
+		Long: "The health-check command checks the web application, Keycloak and every subsystem of the CxAST " +
+			"installation at the same time. It fails when any check fails.",
+		Example: heredoc.Doc(
+			`
+			$ cx utils health-check
+			$ cx utils health-check --format json
+		`,
+		),
+		RunE: runHealthCheck(healthCheckWrapper),
+	}
+	healthCheckCmd.PersistentFlags().String(params.FormatFlag, printer.FormatTable, "Output in table/json/list format")
+	return healthCheckCmd
+}
+
+func runHealthCheck(healthCheckWrapper wrappers.HealthCheckWrapper) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString(params.FormatFlag)
+		checks := getHealthChecks(healthCheckWrapper)
+		views := make([]healthCheckView, len(checks))
+		var wg sync.WaitGroup
+		for i := range checks {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				views[i] = runCheck(checks[i])
+			}(i)
+		}
+		wg.Wait()
+
+		err := printer.Print(cmd.OutOrStdout(), views, format)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, view := range views {
+			if view.Status != healthCheckSuccess {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return errors.Errorf("%d of %d health checks failed", failed, len(views))
+		}
+		return nil
+	}
+}
+
+func getHealthChecks(healthCheckWrapper wrappers.HealthCheckWrapper) []healthCheck {
+	subsystem := func(key string) func() error {
+		return func() error {
+			status, err := healthCheckWrapper.CheckSubsystem(viper.GetString(key))
+			if err != nil {
+				return err
+			}
+			if status == nil || !status.Success {
+				message := "Unhealthy"
+				if status != nil && status.Message != "" {
+					message = status.Message
+				}
+				return errors.New(message)
+			}
+			return nil
+		}
+	}
+	return []healthCheck{
+		{"Web app", healthCheckWrapper.CheckWebApp},
+		{"Keycloak web app", healthCheckWrapper.CheckKeycloakWebApp},
+		{"Database", subsystem(params.HealthcheckDBPathKey)},
+		{"Message queue", subsystem(params.HealthcheckMessageQueuePathKey)},
+		{"Object store", subsystem(params.HealthcheckObjectStorePathKey)},
+		{"In-memory database", subsystem(params.HealthcheckInMemoryDBPathKey)},
+		{"Logging", subsystem(params.HealthcheckLoggingPathKey)},
+		{"Scan flow", subsystem(params.HealthcheckScanFlowPathKey)},
+		{"SAST engines", subsystem(params.HealthcheckSastEnginesPathKey)},
+	}
+}
+
+func runCheck(check healthCheck) healthCheckView {
+	start := time.Now()
+	err := check.run()
+	view := healthCheckView{
+		Name:      check.name,
+		Status:    healthCheckSuccess,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		view.Status = healthCheckFailure
+		view.Details = err.Error()
+	}
+	return view
+}
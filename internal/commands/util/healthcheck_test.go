@@ -0,0 +1,48 @@
+//go:build !integration
+
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/spf13/viper"
+
+	"gotest.tools/assert"
+)
+
+func TestHealthCheckHelp(t *testing.T) {
+	cmd := NewHealthCheckCommand(nil)
+	cmd.SetArgs([]string{"--help"})
+	err := cmd.Execute()
+	assert.NilError(t, err)
+}
+
+func TestHealthCheckMock(t *testing.T) {
+	cmd := NewHealthCheckCommand(&mock.HealthCheckMockWrapper{})
+	cmd.SetArgs([]string{"--format", "json"})
+	err := cmd.Execute()
+	assert.NilError(t, err, "Health check command should succeed when every check succeeds")
+}
+
+func TestHealthCheckMockFailure(t *testing.T) {
+	viper.Set(params.HealthcheckLoggingPathKey, "logging")
+	defer viper.Set(params.HealthcheckLoggingPathKey, nil)
+	buffer := bytes.NewBufferString("")
+	cmd := NewHealthCheckCommand(&mock.HealthCheckMockWrapper{FailingSubsystem: "logging"})
+	cmd.SetOut(buffer)
+	cmd.SetArgs([]string{"--format", "table"})
+	err := cmd.Execute()
+	assert.Error(t, err, "1 of 9 health checks failed")
+	assert.Assert(t, strings.Contains(buffer.String(), "MOCK is down"), buffer.String())
+}
+
+func TestHealthCheckMockInvalidFormat(t *testing.T) {
+	cmd := NewHealthCheckCommand(&mock.HealthCheckMockWrapper{})
+	cmd.SetArgs([]string{"--format", "MOCK"})
+	err := cmd.Execute()
+	assert.Error(t, err, "Invalid format MOCK")
+}
@@ -1,7 +1,12 @@
 package util
 
 import (
+	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/checkmarx/ast-cli/internal/commands/util/printer"
@@ -9,11 +14,17 @@ import (
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 const (
-	invalidFlag   = "Value of %s is invalid"
-	defaultFormat = "list"
+	invalidFlag                = "Value of %s is invalid"
+	defaultFormat              = "list"
+	missingQueryIDs            = "Please provide --query-id or --scan-id"
+	noSastQueries              = "The scan %s has no SAST results"
+	missingCachedDescriptions  = "Warning: %d of %d query descriptions are not cached"
+	failedGettingScanQueries   = "Failed getting the queries of the scan"
+	descriptionsCacheSubfolder = ".checkmarx/cache/descriptions"
 )
 
 type sampleObjectView struct {
@@ -33,13 +44,17 @@ type LearnMoreResponseView struct {
 	Samples                []sampleObjectView `json:"samples"`
 }
 
-func NewLearnMoreCommand(wrapper wrappers.LearnMoreWrapper) *cobra.Command {
+func NewLearnMoreCommand(wrapper wrappers.LearnMoreWrapper, resultsWrapper wrappers.ResultsWrapper) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "learn-more",
 		Short: "Shows the descriptions and additional details for a query id",
+		Long: "The learn-more command shows the descriptions of the given queries, or of every SAST query of a scan. " +
+			"The descriptions are cached on disk so that they are also available offline.",
 		Example: heredoc.Doc(
 			`
-			$ cx utils learn-more
+			$ cx utils learn-more --query-id <query Id>
+			$ cx utils learn-more --query-id <query Id>,<query Id> --format json
+			$ cx utils learn-more --scan-id <scan Id> --offline
 		`,
 		),
 		Annotations: map[string]string{
@@ -49,27 +64,46 @@ func NewLearnMoreCommand(wrapper wrappers.LearnMoreWrapper) *cobra.Command {
 			`,
 			),
 		},
-		RunE: runLearnMoreCmd(wrapper),
+		RunE: runLearnMoreCmd(wrapper, resultsWrapper),
 	}
-	cmd.PersistentFlags().String(params.QueryIDFlag, "", "Query ID is needed")
+	cmd.PersistentFlags().StringSlice(params.QueryIDFlag, []string{}, "Query IDs, comma separated")
+	cmd.PersistentFlags().String(params.ScanIDFlag, "", "Show the descriptions of every SAST query of the scan")
+	cmd.PersistentFlags().Bool(params.OfflineFlag, false, params.OfflineFlagUsage)
 	cmd.PersistentFlags().String(params.FormatFlag, "", "Output in json/list/table format")
-	err := cmd.MarkPersistentFlagRequired(params.QueryIDFlag)
-	if err != nil {
-		log.Fatal(err)
-	}
 	return cmd
 }
 
-func runLearnMoreCmd(wrapper wrappers.LearnMoreWrapper) func(cmd *cobra.Command, args []string) error {
+func runLearnMoreCmd(
+	wrapper wrappers.LearnMoreWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		queryID, _ := cmd.Flags().GetString(params.QueryIDFlag)
-		if queryID == "" {
+		queryIDs, _ := cmd.Flags().GetStringSlice(params.QueryIDFlag)
+		scanID, _ := cmd.Flags().GetString(params.ScanIDFlag)
+		offline, _ := cmd.Flags().GetBool(params.OfflineFlag)
+		if cmd.Flags().Changed(params.QueryIDFlag) && len(strings.Join(queryIDs, "")) == 0 {
 			return errors.Errorf(
 				invalidFlag, params.QueryIDFlag)
 		}
+		if len(queryIDs) == 0 && scanID == "" {
+			return errors.New(missingQueryIDs)
+		}
+		if scanID != "" {
+			scanQueryIDs, err := getScanQueryIDs(resultsWrapper, scanID)
+			if err != nil {
+				return err
+			}
+			if len(scanQueryIDs) == 0 && len(queryIDs) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), noSastQueries+"\n", scanID)
+				return nil
+			}
+			queryIDs = append(queryIDs, scanQueryIDs...)
+		}
+
+		queryIDs = wrappers.DistinctQueryIDs(queryIDs)
 		pathParams := make(map[string]string)
-		pathParams[params.IDsQueryParam] = queryID
-		LearnMoreResponse, errorModel, err := wrapper.GetLearnMoreDetails(pathParams)
+		pathParams[params.IDsQueryParam] = strings.Join(queryIDs, ",")
+		LearnMoreResponse, errorModel, err := NewDescriptionsCache(wrapper, offline).GetLearnMoreDetails(pathParams)
 		if err != nil {
 			return err
 		}
@@ -77,6 +111,9 @@ func runLearnMoreCmd(wrapper wrappers.LearnMoreWrapper) func(cmd *cobra.Command,
 		if errorModel != nil {
 			return errors.Errorf("Failed getting additional details")
 		}
+		if offline && LearnMoreResponse != nil && len(*LearnMoreResponse) < len(queryIDs) {
+			log.Printf(missingCachedDescriptions, len(queryIDs)-len(*LearnMoreResponse), len(queryIDs))
+		}
 
 		if LearnMoreResponse != nil {
 			format, _ := cmd.Flags().GetString(params.FormatFlag)
@@ -93,6 +130,43 @@ func runLearnMoreCmd(wrapper wrappers.LearnMoreWrapper) func(cmd *cobra.Command,
 	}
 }
 
+// NewDescriptionsCache returns the wrapper caching the query descriptions according to the configuration
+func NewDescriptionsCache(wrapper wrappers.LearnMoreWrapper, offline bool) wrappers.LearnMoreWrapper {
+	dir := viper.GetString(params.DescriptionsCacheDirKey)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = os.TempDir()
+		}
+		dir = filepath.Join(home, descriptionsCacheSubfolder)
+	}
+	ttl := time.Duration(viper.GetInt(params.DescriptionsCacheTTLKey)) * time.Hour
+	return wrappers.NewCachedLearnMoreWrapper(wrapper, dir, ttl, offline)
+}
+
+// getScanQueryIDs returns the distinct query ids of the SAST results of the scan
+func getScanQueryIDs(resultsWrapper wrappers.ResultsWrapper, scanID string) ([]string, error) {
+	resultsModel, webError, err := wrappers.NewAllPagesResultsWrapper(resultsWrapper, 1).GetAllResultsByScanID(
+		map[string]string{params.ScanIDQueryParam: scanID},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s", failedGettingScanQueries)
+	}
+	if webError != nil {
+		return nil, errors.Errorf("%s: CODE: %d, %s", failedGettingScanQueries, webError.Code, webError.Message)
+	}
+	var queryIDs []string
+	if resultsModel == nil {
+		return queryIDs, nil
+	}
+	for _, result := range resultsModel.Results {
+		if result != nil && result.Type == params.SastType && result.ScanResultData.QueryID != nil {
+			queryIDs = append(queryIDs, wrappers.FormatQueryID(result.ScanResultData.QueryID))
+		}
+	}
+	return wrappers.DistinctQueryIDs(queryIDs), nil
+}
+
 func toLearnMoreResponseView(response *[]*wrappers.LearnMoreResponse) interface{} {
 	var learnMoreResponseView []*LearnMoreResponseView
 	for _, resp := range *response {
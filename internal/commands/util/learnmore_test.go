@@ -3,63 +3,163 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
+	"github.com/checkmarx/ast-cli/internal/params"
 	"github.com/checkmarx/ast-cli/internal/wrappers/mock"
+	"github.com/spf13/viper"
 
 	"gotest.tools/assert"
 )
 
+func useDescriptionsCache(t *testing.T, ttlHours int) string {
+	dir := t.TempDir()
+	viper.Set(params.DescriptionsCacheDirKey, dir)
+	viper.Set(params.DescriptionsCacheTTLKey, ttlHours)
+	t.Cleanup(
+		func() {
+			viper.Set(params.DescriptionsCacheDirKey, "")
+			viper.Set(params.DescriptionsCacheTTLKey, "")
+		},
+	)
+	return dir
+}
+
+func runLearnMore(t *testing.T, wrapper mock.LearnMoreMockWrapper, args ...string) []LearnMoreResponseView {
+	cmd := NewLearnMoreCommand(wrapper, mock.ResultsMockWrapper{})
+	buffer := bytes.NewBufferString("")
+	cmd.SetOut(buffer)
+	cmd.SetArgs(append(args, "--format", "json"))
+	err := cmd.Execute()
+	assert.NilError(t, err)
+	var views []LearnMoreResponseView
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &views))
+	return views
+}
+
 func TestLearnMoreHelp(t *testing.T) {
-	cmd := NewLearnMoreCommand(nil)
+	cmd := NewLearnMoreCommand(nil, nil)
 	cmd.SetArgs([]string{"utils", "learn-more", "--help"})
 	err := cmd.Execute()
 	assert.Assert(t, err == nil)
 }
 
 func TestLearnMoreMockQueryIdSummaryConsole(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more", "--query-id", "MOCK"})
 	err := cmd.Execute()
 	assert.NilError(t, err, "Learn more command should run with no errors and print to console")
 }
 
 func TestLearnMoreMockQueryIdJsonFormat(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more", "--query-id", "MOCK", "--format", "json"})
 	err := cmd.Execute()
 	assert.NilError(t, err, "Learn more command should run with no errors and print to json")
 }
 
 func TestLearnMoreMockQueryIdListFormat(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more", "--query-id", "MOCK", "--format", "list"})
 	err := cmd.Execute()
 	assert.NilError(t, err, "Learn more command should run with no errors and print to list")
 }
 
 func TestLearnMoreMockQueryIdTableFormat(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more", "--query-id", "MOCK", "--format", "table"})
 	err := cmd.Execute()
 	assert.NilError(t, err, "Learn more command should run with no errors and print to table")
 }
 
 func TestLearnMoreMockMissingQueryId(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more"})
 	err := cmd.Execute()
-	assert.Assert(t, err.Error() == "required flag(s) \"query-id\" not set")
+	assert.Assert(t, err.Error() == "Please provide --query-id or --scan-id")
 }
 
 func TestLearnMoreMockQueryIdInvalidFormat(t *testing.T) {
-	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{})
+	useDescriptionsCache(t, 0)
+	cmd := NewLearnMoreCommand(mock.LearnMoreMockWrapper{}, mock.ResultsMockWrapper{})
 	cmd.SetArgs([]string{"utils", "learn-more", "--query-id", "MOCK", "--format", "MOCK"})
 	err := cmd.Execute()
 	assert.Assert(t, err.Error() == "Invalid format MOCK")
 }
 
 func TestCommand(t *testing.T) {
-	cmd := NewLearnMoreCommand(nil)
+	cmd := NewLearnMoreCommand(nil, nil)
 	assert.Assert(t, cmd != nil, "Learnmore command must exist")
 }
+
+func TestLearnMoreMultipleQueryIds(t *testing.T) {
+	useDescriptionsCache(t, 0)
+	views := runLearnMore(t, mock.LearnMoreMockWrapper{}, "--query-id", "1,2", "--query-id", "1,3")
+	assert.Equal(t, len(views), 3)
+	assert.Equal(t, views[0].QueryID, "1")
+	assert.Equal(t, views[1].QueryID, "2")
+	assert.Equal(t, views[2].QueryID, "3")
+}
+
+func TestLearnMoreScanId(t *testing.T) {
+	useDescriptionsCache(t, 0)
+	views := runLearnMore(t, mock.LearnMoreMockWrapper{}, "--scan-id", "MOCK")
+	assert.Equal(t, len(views), 1)
+	assert.Equal(t, views[0].QueryID, "5157925289005576664")
+}
+
+func TestLearnMoreCache(t *testing.T) {
+	useDescriptionsCache(t, 1)
+	requests := 0
+	wrapper := mock.LearnMoreMockWrapper{Requests: &requests}
+
+	runLearnMore(t, wrapper, "--query-id", "1,2")
+	assert.Equal(t, requests, 1)
+	views := runLearnMore(t, wrapper, "--query-id", "2,1")
+	assert.Equal(t, requests, 1, "Cached descriptions should not be fetched again")
+	assert.Equal(t, views[0].QueryID, "2")
+	runLearnMore(t, wrapper, "--query-id", "1,3")
+	assert.Equal(t, requests, 2, "Only the missing descriptions should be fetched")
+}
+
+func TestLearnMoreCacheExpired(t *testing.T) {
+	useDescriptionsCache(t, 0)
+	requests := 0
+	wrapper := mock.LearnMoreMockWrapper{Requests: &requests}
+
+	runLearnMore(t, wrapper, "--query-id", "1")
+	runLearnMore(t, wrapper, "--query-id", "1")
+	assert.Equal(t, requests, 2)
+}
+
+func TestLearnMoreOffline(t *testing.T) {
+	useDescriptionsCache(t, 0)
+	requests := 0
+	wrapper := mock.LearnMoreMockWrapper{Requests: &requests}
+
+	runLearnMore(t, wrapper, "--query-id", "1")
+	views := runLearnMore(t, wrapper, "--query-id", "1,2", "--offline")
+	assert.Equal(t, requests, 1, "Offline should only read the cache")
+	assert.Equal(t, len(views), 1)
+	assert.Equal(t, views[0].QueryID, "1")
+}
+
+func TestLearnMoreCacheNotWritable(t *testing.T) {
+	dir := useDescriptionsCache(t, 1)
+	file := filepath.Join(dir, "file")
+	assert.NilError(t, ioutil.WriteFile(file, []byte{}, 0600))
+	viper.Set(params.DescriptionsCacheDirKey, filepath.Join(file, "cache"))
+
+	views := runLearnMore(t, mock.LearnMoreMockWrapper{}, "--query-id", "1")
+	assert.Equal(t, len(views), 1, "A failed cache write should not lose the fetched description")
+	assert.Equal(t, views[0].QueryID, "1")
+}
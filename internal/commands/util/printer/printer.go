@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,8 @@ const (
 	FormatList           = "list"
 	FormatTable          = "table"
 	FormatHTML           = "html"
+	FormatCSV            = "csv"
+	FormatMarkdown       = "markdown"
 )
 
 func Print(w io.Writer, view interface{}, format string) error {
@@ -37,6 +40,9 @@ func Print(w io.Writer, view interface{}, format string) error {
 	} else if IsFormat(format, FormatTable) {
 		entities := toEntities(view)
 		printTable(w, entities)
+	} else if IsFormat(format, FormatCSV) {
+		entities := toEntities(view)
+		return printCSV(w, entities)
 	} else {
 		return errors.Errorf("Invalid format %s", format)
 	}
@@ -128,6 +134,27 @@ func printTable(w io.Writer, entities []*entity) {
 	_, _ = fmt.Fprintln(w)
 }
 
+func printCSV(w io.Writer, entities []*entity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, len(entities[0].Properties))
+	for i, p := range entities[0].Properties {
+		header[i] = p.Key
+	}
+	_ = writer.Write(header)
+	for _, e := range entities {
+		row := make([]string, len(e.Properties))
+		for i, p := range e.Properties {
+			row[i] = p.Value
+		}
+		_ = writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
 func pad(width int, key string) string {
 	padLen := width - len(key) + 1
 	const nonBreakingSpace = string('\u00A0')
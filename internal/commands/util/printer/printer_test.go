@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
@@ -46,3 +47,18 @@ func TestPrintTable(t *testing.T) {
 	err = Print(os.Stdout, []string{"column1", "column2", "column3"}, FormatTable)
 	assert.NilError(t, err, "table print must run well")
 }
+
+func TestPrintCSV(t *testing.T) {
+	type view struct {
+		Name  string
+		Count int `format:"name:Total count"`
+	}
+	var buffer bytes.Buffer
+	err := Print(&buffer, []view{{Name: "a,b", Count: 1}, {Name: "c", Count: 2}}, FormatCSV)
+	assert.NilError(t, err, "csv print must run well")
+	assert.Equal(t, buffer.String(), "Name,Total count\n\"a,b\",1\nc,2\n")
+
+	buffer.Reset()
+	err = Print(&buffer, nil, FormatCSV)
+	assert.NilError(t, err, "csv print must run well")
+}
@@ -0,0 +1,171 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
+
+const (
+	refreshInterval = 100 * time.Millisecond
+	clearLine       = "\r\033[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Renderer shows the progress of a scan while the CLI waits for it
+type Renderer interface {
+	// Update is called on every poll with the latest scan information
+	Update(scan *wrappers.ScanResponseModel)
+	// Done stops the rendering, it can be called more than once
+	Done()
+}
+
+// NewRenderer returns an animated renderer when the output is an interactive terminal,
+// otherwise a renderer that keeps printing plain log lines
+func NewRenderer(out io.Writer, enabled bool) Renderer {
+	if enabled && IsTerminal(out) {
+		return NewTerminalRenderer(out)
+	}
+	return NewLogRenderer()
+}
+
+// NewLogRenderer returns a renderer that prints a log line on every update
+func NewLogRenderer() Renderer {
+	return &logRenderer{}
+}
+
+// IsTerminal reports if the writer is a character device such as an interactive terminal
+func IsTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// FormatStatusDetails summarizes the status of each engine, ex: (sast: Running, kics: Completed)
+func FormatStatusDetails(statusDetails []wrappers.StatusInfo) string {
+	var engines []string
+	for _, statusInfo := range statusDetails {
+		engines = append(engines, fmt.Sprintf("%s: %s", statusInfo.Name, statusInfo.Status))
+	}
+	if len(engines) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%s)", strings.Join(engines, ", "))
+}
+
+type logRenderer struct{}
+
+func (r *logRenderer) Update(scan *wrappers.ScanResponseModel) {
+	log.Println("Scan status: ", scan.Status, FormatStatusDetails(scan.StatusDetails))
+}
+
+func (r *logRenderer) Done() {}
+
+// TerminalRenderer redraws a single status line with a spinner, the elapsed time, the queue position and
+// the status of each engine
+type TerminalRenderer struct {
+	out     io.Writer
+	mutex   sync.Mutex
+	scan    *wrappers.ScanResponseModel
+	start   time.Time
+	frame   int
+	stop    chan struct{}
+	stopped sync.WaitGroup
+	once    sync.Once
+}
+
+func NewTerminalRenderer(out io.Writer) *TerminalRenderer {
+	r := &TerminalRenderer{
+		out:   out,
+		start: time.Now(),
+		stop:  make(chan struct{}),
+	}
+	r.stopped.Add(1)
+	go r.loop()
+	return r
+}
+
+func (r *TerminalRenderer) Update(scan *wrappers.ScanResponseModel) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.scan == nil && !scan.CreatedAt.IsZero() && scan.CreatedAt.Before(r.start) {
+		r.start = scan.CreatedAt
+	}
+	r.scan = scan
+	r.render()
+}
+
+func (r *TerminalRenderer) Done() {
+	r.once.Do(
+		func() {
+			close(r.stop)
+			r.stopped.Wait()
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			_, _ = fmt.Fprint(r.out, clearLine)
+		},
+	)
+}
+
+func (r *TerminalRenderer) loop() {
+	defer r.stopped.Done()
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.mutex.Lock()
+			r.frame = (r.frame + 1) % len(spinnerFrames)
+			r.render()
+			r.mutex.Unlock()
+		}
+	}
+}
+
+func (r *TerminalRenderer) render() {
+	_, _ = fmt.Fprint(r.out, clearLine+r.line())
+}
+
+func (r *TerminalRenderer) line() string {
+	var parts []string
+	if r.scan == nil {
+		parts = append(parts, "Waiting for scan")
+	} else {
+		parts = append(parts, string(r.scan.Status))
+	}
+	parts = append(parts, formatElapsed(time.Since(r.start)))
+	if r.scan != nil {
+		if r.scan.PositionInQueue != nil && r.scan.Status == wrappers.ScanQueued {
+			parts = append(parts, fmt.Sprintf("position in queue: %d", *r.scan.PositionInQueue))
+		}
+		if details := FormatStatusDetails(r.scan.StatusDetails); details != "" {
+			parts = append(parts, details)
+		}
+	}
+	return spinnerFrames[r.frame] + " " + strings.Join(parts, " | ")
+}
+
+func formatElapsed(elapsed time.Duration) string {
+	elapsed = elapsed.Round(time.Second)
+	hours := elapsed / time.Hour
+	elapsed -= hours * time.Hour
+	minutes := elapsed / time.Minute
+	elapsed -= minutes * time.Minute
+	seconds := elapsed / time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
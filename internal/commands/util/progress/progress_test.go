@@ -0,0 +1,50 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"gotest.tools/assert"
+)
+
+func TestNewRendererNotTerminal(t *testing.T) {
+	renderer := NewRenderer(&bytes.Buffer{}, true)
+	_, isLogRenderer := renderer.(*logRenderer)
+	assert.Assert(t, isLogRenderer, "A buffer is not a terminal")
+	renderer.Update(&wrappers.ScanResponseModel{Status: wrappers.ScanRunning})
+	renderer.Done()
+}
+
+func TestTerminalRenderer(t *testing.T) {
+	var out bytes.Buffer
+	position := uint(3)
+	renderer := NewTerminalRenderer(&out)
+	renderer.Update(
+		&wrappers.ScanResponseModel{
+			Status:          wrappers.ScanQueued,
+			PositionInQueue: &position,
+			StatusDetails: []wrappers.StatusInfo{
+				{Name: "sast", Status: "Queued"},
+				{Name: "kics", Status: "Completed"},
+			},
+		},
+	)
+	renderer.Done()
+	renderer.Done()
+	rendered := out.String()
+	assert.Assert(t, strings.Contains(rendered, "Queued | 00:00:00 | position in queue: 3"), rendered)
+	assert.Assert(t, strings.Contains(rendered, "(sast: Queued, kics: Completed)"), rendered)
+	assert.Assert(t, strings.HasSuffix(rendered, clearLine), "The line must be cleared when done")
+}
+
+func TestFormatStatusDetails(t *testing.T) {
+	assert.Equal(t, FormatStatusDetails(nil), "")
+	assert.Equal(t, FormatStatusDetails([]wrappers.StatusInfo{{Name: "sca", Status: "Running"}}), "(sca: Running)")
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, formatElapsed(time.Hour+2*time.Minute+3*time.Second), "01:02:03")
+}
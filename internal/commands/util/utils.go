@@ -18,7 +18,9 @@ func NewUtilsCommand(gitHubWrapper wrappers.GitHubWrapper,
 	azureWrapper wrappers.AzureWrapper,
 	bitBucketWrapper wrappers.BitBucketWrapper,
 	gitLabWrapper wrappers.GitLabWrapper,
-	learnMoreWrapper wrappers.LearnMoreWrapper) *cobra.Command {
+	learnMoreWrapper wrappers.LearnMoreWrapper,
+	resultsWrapper wrappers.ResultsWrapper,
+	healthCheckWrapper wrappers.HealthCheckWrapper) *cobra.Command {
 	utilsCmd := &cobra.Command{
 		Use:   "utils",
 		Short: "Utility functions",
@@ -42,9 +44,18 @@ func NewUtilsCommand(gitHubWrapper wrappers.GitHubWrapper,
 
 	remediationCmd := NewRemediationCommand()
 
-	learnMoreCmd := NewLearnMoreCommand(learnMoreWrapper)
+	learnMoreCmd := NewLearnMoreCommand(learnMoreWrapper, resultsWrapper)
 
-	utilsCmd.AddCommand(completionCmd, envCheckCmd, learnMoreCmd, usercount.NewUserCountCommand(gitHubWrapper, azureWrapper, bitBucketWrapper, gitLabWrapper), remediationCmd)
+	healthCheckCmd := NewHealthCheckCommand(healthCheckWrapper)
+
+	utilsCmd.AddCommand(
+		completionCmd,
+		envCheckCmd,
+		learnMoreCmd,
+		usercount.NewUserCountCommand(gitHubWrapper, azureWrapper, bitBucketWrapper, gitLabWrapper),
+		remediationCmd,
+		healthCheckCmd,
+	)
 
 	return utilsCmd
 }
@@ -7,8 +7,15 @@ var EnvVarsBinds = []struct {
 }{
 	{BaseURIKey, BaseURIEnv, ""},
 	{ProxyKey, ProxyEnv, ""},
+	{HTTPSProxyKey, HTTPSProxyEnv, ""},
+	{NoProxyKey, NoProxyEnv, ""},
+	{ProxyRulesKey, ProxyRulesEnv, ""},
 	{ProxyTypeKey, ProxyTypeEnv, "basic"},
 	{ProxyDomainKey, ProxyDomainEnv, ""},
+	{ProxyKrb5ConfKey, ProxyKrb5ConfEnv, ""},
+	{ProxyKeytabKey, ProxyKeytabEnv, ""},
+	{ProxyPrincipalKey, ProxyPrincipalEnv, ""},
+	{ProxyCCacheKey, ProxyCCacheEnv, ""},
 	{BaseAuthURIKey, BaseAuthURIEnv, ""},
 	{AstAPIKey, AstAPIKeyEnv, ""},
 	{AgentNameKey, AgentNameEnv, "ASTCLI"},
@@ -24,6 +31,8 @@ var EnvVarsBinds = []struct {
 	{KicsResultsPredicatesPathKey, KicsResultsPredicatesPathEnv, "api/kics-results-predicates"},
 	{BflPathKey, BflPathEnv, "api/bfl"},
 	{DescriptionsPathKey, DescriptionsPathEnv, "api/queries/descriptions"},
+	{DescriptionsCacheDirKey, DescriptionsCacheDirEnv, ""},
+	{DescriptionsCacheTTLKey, DescriptionsCacheTTLEnv, "168"},
 	{UploadsPathKey, UploadsPathEnv, "api/uploads"},
 	{SastRmPathKey, SastRmPathEnv, "api/sast-rm"},
 	{AstWebAppHealthCheckPathKey, AstWebAppHealthCheckPathEnv, "#/projects"},
@@ -51,4 +60,9 @@ var EnvVarsBinds = []struct {
 	{AstRoleKey, AstRoleEnv, ScaAgent},
 	{TokenExpirySecondsKey, TokenExpirySecondsEnv, "300"},
 	{ClientTimeoutKey, ClientTimeoutEnv, "5"},
+	{HookSecretKey, HookSecretEnv, ""},
+	{CACertKey, CACertEnv, ""},
+	{ClientCertKey, ClientCertEnv, ""},
+	{ClientKeyKey, ClientKeyEnv, ""},
+	{MinTLSVersionKey, MinTLSVersionEnv, ""},
 }
@@ -6,8 +6,15 @@ const (
 	BaseURIEnv                          = "CX_BASE_URI"
 	ClientTimeoutEnv                    = "CX_TIMEOUT"
 	ProxyEnv                            = "HTTP_PROXY"
+	HTTPSProxyEnv                       = "HTTPS_PROXY"
+	NoProxyEnv                          = "NO_PROXY"
+	ProxyRulesEnv                       = "CX_PROXY_RULES"
 	ProxyTypeEnv                        = "CX_PROXY_AUTH_TYPE"
 	ProxyDomainEnv                      = "CX_PROXY_NTLM_DOMAIN"
+	ProxyKrb5ConfEnv                    = "CX_PROXY_KERBEROS_KRB5_CONF"
+	ProxyKeytabEnv                      = "CX_PROXY_KERBEROS_KEYTAB"
+	ProxyPrincipalEnv                   = "CX_PROXY_KERBEROS_PRINCIPAL"
+	ProxyCCacheEnv                      = "CX_PROXY_KERBEROS_CCACHE"
 	BaseAuthURIEnv                      = "CX_BASE_AUTH_URI"
 	AstAPIKeyEnv                        = "CX_APIKEY"
 	AccessKeyIDEnv                      = "CX_CLIENT_ID"
@@ -47,4 +54,11 @@ const (
 	LogsPathEnv                         = "CX_LOGS_PATH"
 	LogsEngineLogPathEnv                = "CX_LOGS_ENGINE_LOG_PATH"
 	DescriptionsPathEnv                 = "CX_DESCRIPTIONS_PATH"
+	DescriptionsCacheDirEnv             = "CX_DESCRIPTIONS_CACHE_DIR"
+	DescriptionsCacheTTLEnv             = "CX_DESCRIPTIONS_CACHE_TTL"
+	HookSecretEnv                       = "CX_HOOK_SECRET"
+	CACertEnv                           = "CX_CA_CERT"
+	ClientCertEnv                       = "CX_CLIENT_CERT"
+	ClientKeyEnv                        = "CX_CLIENT_KEY"
+	MinTLSVersionEnv                    = "CX_MIN_TLS_VERSION"
 )
@@ -38,6 +38,14 @@ const (
 	AccessKeySecretFlagUsage     = "The OAuth2 client secret"
 	InsecureFlag                 = "insecure"
 	InsecureFlagUsage            = "Ignore TLS certificate validations"
+	CACertFlag                   = "ca-cert"
+	CACertFlagUsage              = "PEM bundle of CA certificates trusted in addition to the system ones"
+	ClientCertFlag               = "client-cert"
+	ClientCertFlagUsage          = "PEM client certificate for mutual TLS, requires --client-key"
+	ClientKeyFlag                = "client-key"
+	ClientKeyFlagUsage           = "PEM private key of the client certificate for mutual TLS"
+	MinTLSVersionFlag            = "min-tls-version"
+	MinTLSVersionFlagUsage       = "Minimum TLS version, one of 1.0, 1.1, 1.2 or 1.3"
 	ScanInfoFormatFlag           = "scan-info-format"
 	FormatFlag                   = "format"
 	FormatFlagUsageFormat        = "Format for the output. One of %s"
@@ -45,12 +53,24 @@ const (
 	BaseURIFlag                  = "base-uri"
 	ProxyFlag                    = "proxy"
 	ProxyFlagUsage               = "Proxy server to send communication through"
+	NoProxyFlag                  = "no-proxy"
+	NoProxyFlagUsage             = "Comma separated hosts, domains or CIDRs reached without a proxy"
+	ProxyRulesFlag               = "proxy-rules"
+	ProxyRulesFlagUsage          = "Comma separated per-host proxy rules <host>=<proxy URL or direct>, first match wins"
 	ProxyTypeFlag                = "proxy-auth-type"
-	ProxyTypeFlagUsage           = "Proxy authentication type, (basic or ntlm)"
+	ProxyTypeFlagUsage           = "Proxy authentication type, (basic, ntlm or negotiate)"
 	TimeoutFlag                  = "timeout"
 	TimeoutFlagUsage             = "Timeout for network activity, (default 5 seconds)"
 	NtlmProxyDomainFlag          = "proxy-ntlm-domain"
 	NtlmProxyDomainFlagUsage     = "Window domain when using NTLM proxy"
+	KrbProxyConfFlag             = "proxy-kerberos-krb5-conf"
+	KrbProxyConfFlagUsage        = "Kerberos configuration when using Negotiate proxy, (default KRB5_CONFIG or /etc/krb5.conf)"
+	KrbProxyKeytabFlag           = "proxy-kerberos-keytab"
+	KrbProxyKeytabFlagUsage      = "Keytab of the principal when using Negotiate proxy, the credentials cache is used otherwise"
+	KrbProxyPrincipalFlag        = "proxy-kerberos-principal"
+	KrbProxyPrincipalFlagUsage   = "Principal of the keytab when using Negotiate proxy, for example user@REALM"
+	KrbProxyCCacheFlag           = "proxy-kerberos-ccache"
+	KrbProxyCCacheFlagUsage      = "Kerberos credentials cache when using Negotiate proxy, (default KRB5CCNAME)"
 	BaseURIFlagUsage             = "The base system URI"
 	BaseAuthURIFlag              = "base-auth-uri"
 	BaseAuthURIFlagUsage         = "The base system IAM URI"
@@ -107,6 +127,8 @@ const (
 	GitLabURLFlag                = "url-gitlab"
 	URLFlagUsage                 = "API base URL"
 	QueryIDFlag                  = "query-id"
+	OfflineFlag                  = "offline"
+	OfflineFlagUsage             = "Only use the cached query descriptions, however old"
 	SSHKeyFlag                   = "ssh-key"
 	RepoURLFlag                  = "repo-url"
 	AstToken                     = "ast-token"
@@ -114,6 +136,85 @@ const (
 	KicsContainerNameKey         = "kics-container-name"
 	KicsPlatformsFlag            = "kics-platforms"
 	KicsPlatformsFlagUsage       = "KICS Platform Flag"
+	ManifestFlag                 = "manifest"
+	ManifestFlagUsage            = "Manifest file describing several projects to scan from the same repository"
+	ManifestParallelismFlag      = "manifest-parallelism"
+	ManifestParallelismDefault   = 4
+	ManifestParallelismUsage     = "Maximum number of manifest projects scanned at the same time"
+	NoProgressFlag               = "no-progress"
+	NoProgressFlagUsage          = "Print plain log lines instead of the live progress while waiting for the scan"
+	HookURLFlag                  = "hook-url"
+	HookURLFlagUsage             = "URLs receiving a JSON POST on scan lifecycle events"
+	HookCommandFlag              = "hook-command"
+	HookCommandFlagUsage         = "Local commands receiving the JSON event on the standard input"
+	HookEventsFlag               = "hook-events"
+	HookEventsFlagUsage          = "Events triggering the hooks, all by default. Available events: %s"
+	HookSecretFlag               = "hook-secret"
+	HookSecretFlagUsage          = "Shared secret used to sign the hook payloads with HMAC-SHA256"
+	HookRetriesFlag              = "hook-retries"
+	HookRetriesFlagUsage         = "Retries for each hook delivery"
+	TriageFileFlag               = "file"
+	TriageFileFlagUsage          = "CSV or JSON file with similarityId, projectId, scannerType, state, severity and comment"
+	FileGlobFlag                 = "file-glob"
+	FileGlobFlagUsage            = "Only include results in files matching the glob, ex: src/test/**"
+	DryRunFlag                   = "dry-run"
+	DryRunFlagUsage              = "Preview the changes without applying them"
+	ConcurrencyFlag              = "concurrency"
+	ConcurrencyDefault           = 4
+	ConcurrencyFlagUsage         = "Number of requests sent at the same time"
+	RateLimitFlag                = "rate-limit"
+	RateLimitDefault             = 10
+	RateLimitFlagUsage           = "Maximum number of requests per second, 0 for unlimited"
+	RetryFileFlag                = "retry-file"
+	RetryFileDefault             = "triage-failed.csv"
+	RetryFileFlagUsage           = "File receiving the items that failed, in the same format as --file"
+	TriageHistoryFlag            = "include-triage-history"
+	TriageHistoryFlagUsage       = "Add the triage history of every result to the reports"
+	EnrichFlag                   = "enrich"
+	EnrichFlagUsage              = "Add the learn more guidance and the CodeBashing lesson of every SAST query to the reports"
+	GroupByFlag                  = "group-by"
+	GroupByFlagUsage             = "Group the results in the reports. Available groupings: bfl (best fix location)"
+	ResultIDFlag                 = "result-id"
+	ResultIDFlagUsage            = "IDs of the SAST results to trace, every SAST result of the scan when empty"
+	TraceGraphFlag               = "graph"
+	TraceGraphFlagUsage          = "Graph to export: path (data flow) or bfl (best fix location tree)"
+	TraceOutputPathFlagUsage     = "Directory receiving one file per result, the graphs are printed when empty"
+	SourceDirFlag                = "source-dir"
+	SourceDirFlagUsage           = "Local checkout of the scanned sources, used to add code snippets to the results"
+	SnippetContextFlag           = "snippet-context"
+	SnippetContextDefault        = 2
+	SnippetContextFlagUsage      = "Number of lines shown before and after the line of each code snippet"
+	TriageRulesFlag              = "rules"
+	TriageRulesFlagUsage         = "YAML file with the triage rules"
+	AuditLogFlag                 = "audit-log"
+	AuditLogDefault              = "triage-audit.jsonl"
+	AuditLogFlagUsage            = "File where every change is appended as a JSON line"
+	ConfigKeyFlag                = "key"
+	ConfigKeyFlagUsage           = "Configuration key, ex: scan.config.sast.incremental"
+	ConfigValueFlag              = "value"
+	ConfigValueFlagUsage         = "Configuration value, validated against the type of the key"
+	ConfigFileFlag               = "file"
+	ConfigFileFlagUsage          = "YAML file with the project configuration"
+	SCMFlag                      = "scm"
+	SCMFlagUsage                 = "Source control manager hosting the repositories. Available values: %s"
+	SCMOrgsFlag                  = "orgs"
+	SCMOrgsFlagUsage             = "Organizations to import, groups in GitLab and workspaces in Bitbucket"
+	SCMURLFlagUsage              = "API base URL, the public instance of the source control manager by default"
+	KeepLastFlag                 = "keep-last"
+	KeepLastFlagUsage            = "Only prune scans older than the last N scans of each project branch"
+	OlderThanFlag                = "older-than"
+	OlderThanFlagUsage           = "Only prune scans created more than N days ago"
+	NewerThanFlag                = "newer-than"
+	NewerThanFlagUsage           = "Only prune scans created less than N days ago, higher than --older-than when both are given"
+	PruneStatusesFlag            = "statuses"
+	PruneStatusesFlagUsage       = "Only prune scans with these statuses, ex: Failed,Canceled"
+	YesFlag                      = "yes"
+	YesFlagUsage                 = "Skip the confirmation prompt"
+	AllPagesFlag                 = "all"
+	AllPagesFlagUsage            = "Fetch every page instead of the first one, the filter limit is used as the page size"
+	ReportFileFlag               = "report-file"
+	DoctorReportFileDefault      = "cx-doctor-report.json"
+	DoctorReportFileFlagUsage    = "File receiving the redacted diagnostics report to attach to support tickets"
 
 	// INDIVIDUAL FILTER FLAGS
 	SastFilterFlag  = "sast-filter"
@@ -149,6 +250,7 @@ const (
 	NodeIDsQueryParam      = "node-ids"
 	IncludeNodesQueryParam = "include-nodes"
 	SortQueryParam         = "sort"
+	BranchQueryParam       = "branch"
 	Profile                = "default"
 	BaseURI                = ""
 	BaseIAMURI             = ""
@@ -7,8 +7,15 @@ var (
 	BranchKey                           = strings.ToLower(BranchEnv)
 	BaseURIKey                          = strings.ToLower(BaseURIEnv)
 	ProxyKey                            = strings.ToLower(ProxyEnv)
+	HTTPSProxyKey                       = strings.ToLower(HTTPSProxyEnv)
+	NoProxyKey                          = strings.ToLower(NoProxyEnv)
+	ProxyRulesKey                       = strings.ToLower(ProxyRulesEnv)
 	ProxyTypeKey                        = strings.ToLower(ProxyTypeEnv)
 	ProxyDomainKey                      = strings.ToLower(ProxyDomainEnv)
+	ProxyKrb5ConfKey                    = strings.ToLower(ProxyKrb5ConfEnv)
+	ProxyKeytabKey                      = strings.ToLower(ProxyKeytabEnv)
+	ProxyPrincipalKey                   = strings.ToLower(ProxyPrincipalEnv)
+	ProxyCCacheKey                      = strings.ToLower(ProxyCCacheEnv)
 	BaseAuthURIKey                      = strings.ToLower(BaseAuthURIEnv)
 	ClientTimeoutKey                    = strings.ToLower(ClientTimeoutEnv)
 	AstAPIKey                           = strings.ToLower(AstAPIKeyEnv)
@@ -35,7 +42,7 @@ var (
 	HealthcheckMessageQueuePathKey      = strings.ToLower(HealthcheckMessageQueuePathEnv)
 	HealthcheckObjectStorePathKey       = strings.ToLower(HealthcheckObjectStorePathEnv)
 	HealthcheckInMemoryDBPathKey        = strings.ToLower(HealthcheckInMemoryDBPathEnv)
-	HealthcheckLoggingPathKey           = strings.ToLower(HealthcheckDBPathEnv)
+	HealthcheckLoggingPathKey           = strings.ToLower(HealthcheckLoggingPathEnv)
 	HealthcheckScanFlowPathKey          = strings.ToLower(HealthcheckScanFlowPathEnv)
 	HealthcheckSastEnginesPathKey       = strings.ToLower(HealthcheckSastEnginesPathEnv)
 	QueriesPathKey                      = strings.ToLower(QueriesPathEnv)
@@ -49,4 +56,11 @@ var (
 	KicsResultsPredicatesPathKey        = strings.ToLower(KicsResultsPredicatesPathEnv)
 	ScaPackagePathKey                   = strings.ToLower(ScaPackagePathEnv)
 	DescriptionsPathKey                 = strings.ToLower(DescriptionsPathEnv)
+	DescriptionsCacheDirKey             = strings.ToLower(DescriptionsCacheDirEnv)
+	DescriptionsCacheTTLKey             = strings.ToLower(DescriptionsCacheTTLEnv)
+	HookSecretKey                       = strings.ToLower(HookSecretEnv)
+	CACertKey                           = strings.ToLower(CACertEnv)
+	ClientCertKey                       = strings.ToLower(ClientCertEnv)
+	ClientKeyKey                        = strings.ToLower(ClientKeyEnv)
+	MinTLSVersionKey                    = strings.ToLower(MinTLSVersionEnv)
 )
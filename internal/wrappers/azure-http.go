@@ -16,7 +16,6 @@ import (
 )
 
 type AzureHTTPWrapper struct {
-	client *http.Client
 }
 
 const (
@@ -34,9 +33,7 @@ const (
 )
 
 func NewAzureWrapper() AzureWrapper {
-	return &AzureHTTPWrapper{
-		client: getClient(viper.GetUint(params.ClientTimeoutKey)),
-	}
+	return &AzureHTTPWrapper{}
 }
 
 func (g *AzureHTTPWrapper) GetCommits(url, organizationName, projectName, repositoryName, token string) (
@@ -105,7 +102,7 @@ func (g *AzureHTTPWrapper) get(
 		q.Add(k, v)
 	}
 	req.URL.RawQuery = q.Encode()
-	resp, err := g.client.Do(req)
+	resp, err := getClient(viper.GetUint(params.ClientTimeoutKey)).Do(req)
 
 	if err != nil {
 		return err
@@ -18,7 +18,10 @@ type AzureRootRepo struct {
 }
 
 type AzureRepo struct {
-	Name string `json:"name"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	RemoteURL     string `json:"remoteUrl"`
+	DefaultBranch string `json:"defaultBranch"`
 }
 
 type AzureRootProject struct {
@@ -16,7 +16,6 @@ import (
 )
 
 type BitBucketHTTPWrapper struct {
-	client *http.Client
 }
 
 const (
@@ -34,9 +33,7 @@ const (
 )
 
 func NewBitbucketWrapper() BitBucketWrapper {
-	return &BitBucketHTTPWrapper{
-		client: getClient(viper.GetUint(params.ClientTimeoutKey)),
-	}
+	return &BitBucketHTTPWrapper{}
 }
 
 func (g *BitBucketHTTPWrapper) GetworkspaceUUID(bitBucketURL, workspaceName, bitBucketUsername, bitBucketPassword string) (
@@ -77,7 +74,7 @@ func (g *BitBucketHTTPWrapper) GetCommits(bitBucketURL, workspaceUUID, repoUUID,
 	var queryParams = make(map[string]string)
 	repoURL := fmt.Sprintf(bitBucketBaseCommitURL, bitBucketURL, workspaceUUID, repoUUID)
 	pages, err := getWithPaginationBitBucket(
-		g.client,
+		getClient(viper.GetUint(params.ClientTimeoutKey)),
 		repoURL,
 		encodeBitBucketAuth(bitBucketUsername, bitBucketPassword),
 		commitType,
@@ -116,7 +113,7 @@ func (g *BitBucketHTTPWrapper) GetRepositories(bitBucketURL, workspaceName, bitB
 	var queryParams = make(map[string]string)
 	repoURL := fmt.Sprintf(bitBucketBaseRepoURL, bitBucketURL, workspaceName)
 	pages, err := getWithPaginationBitBucket(
-		g.client,
+		getClient(viper.GetUint(params.ClientTimeoutKey)),
 		repoURL,
 		encodeBitBucketAuth(bitBucketUsername, bitBucketPassword),
 		repoType,
@@ -161,7 +158,7 @@ func (g *BitBucketHTTPWrapper) getFromBitBucket(
 		q.Add(k, v)
 	}
 	req.URL.RawQuery = q.Encode()
-	resp, err := g.client.Do(req)
+	resp, err := getClient(viper.GetUint(params.ClientTimeoutKey)).Do(req)
 	if err != nil {
 		return err
 	}
@@ -30,8 +30,23 @@ type BitBucketRootRepoList struct {
 }
 
 type BitBucketRepo struct {
-	Name string `json:"full_name"`
-	UUID string `json:"uuid"`
+	Name       string              `json:"full_name"`
+	UUID       string              `json:"uuid"`
+	Links      BitBucketRepoLinks  `json:"links"`
+	MainBranch BitBucketMainBranch `json:"mainbranch"`
+}
+
+type BitBucketRepoLinks struct {
+	Clone []BitBucketLink `json:"clone"`
+}
+
+type BitBucketLink struct {
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+type BitBucketMainBranch struct {
+	Name string `json:"name"`
 }
 
 type BitBucketPage struct {
@@ -1,7 +1,9 @@
 package wrappers
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,6 +14,7 @@ import (
 	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt"
@@ -21,6 +24,7 @@ import (
 	"github.com/spf13/viper"
 
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/negotiate"
 	"github.com/checkmarx/ast-cli/internal/wrappers/ntlm"
 )
 
@@ -28,6 +32,7 @@ const (
 	expiryGraceSeconds    = 10
 	NoTimeout             = 0
 	ntlmProxyToken        = "ntlm"
+	negotiateProxyToken   = "negotiate"
 	checkmarxURLError     = "Could not reach provided Checkmarx server"
 	tryPrintOffset        = 2
 	retryLimitPrintOffset = 1
@@ -57,6 +62,38 @@ const audienceClaimKey = "aud"
 var cachedAccessToken string
 var cachedAccessTime time.Time
 
+var minTLSVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsSettings are the settings the TLS configuration is built from
+type tlsSettings struct {
+	insecure       bool
+	caCertPath     string
+	clientCertPath string
+	clientKeyPath  string
+	minTLSVersion  string
+}
+
+// The TLS configuration is cached per settings, getClient runs for every request
+var (
+	tlsConfigMutex    sync.Mutex
+	cachedTLSSettings *tlsSettings
+	cachedTLSConfig   *tls.Config
+)
+
+// failingTransport fails every request with the error that prevented building the client transport
+type failingTransport struct {
+	err error
+}
+
+func (t *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
 func setAgentName(req *http.Request) {
 	agentStr := viper.GetString(commonParams.AgentNameKey) + "/" + commonParams.Version
 	req.Header.Set("User-Agent", agentStr)
@@ -64,13 +101,23 @@ func setAgentName(req *http.Request) {
 
 func getClient(timeout uint) *http.Client {
 	proxyTypeStr := viper.GetString(commonParams.ProxyTypeKey)
-	proxyStr := viper.GetString(commonParams.ProxyKey)
+
+	tlsConfig, err := getTLSConfig()
+	if err != nil {
+		return &http.Client{Transport: &failingTransport{err: err}}
+	}
+	router, err := newProxyRouter()
+	if err != nil {
+		return &http.Client{Transport: &failingTransport{err: err}}
+	}
 
 	var client *http.Client
-	if proxyTypeStr == ntlmProxyToken {
-		client = ntmlProxyClient(timeout, proxyStr)
+	if proxyTypeStr == ntlmProxyToken && router.proxy != nil {
+		client = ntmlProxyClient(timeout, router, tlsConfig)
+	} else if proxyTypeStr == negotiateProxyToken && router.proxy != nil {
+		client = negotiateProxyClient(timeout, router, tlsConfig)
 	} else {
-		client = basicProxyClient(timeout, proxyStr)
+		client = basicProxyClient(timeout, router, tlsConfig)
 	}
 
 	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
@@ -91,40 +138,150 @@ func getClient(timeout uint) *http.Client {
 	return client
 }
 
-func basicProxyClient(timeout uint, proxyStr string) *http.Client {
-	insecure := viper.GetBool("insecure")
-	u, _ := url.Parse(proxyStr)
-	var tr *http.Transport
-	if len(proxyStr) > 0 {
-		logger.PrintIfVerbose("Creating HTTP Client with Proxy: " + proxyStr)
-		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
-			Proxy:           http.ProxyURL(u),
+// getTLSConfig builds the TLS configuration of the clients from the insecure, CA bundle, client certificate and
+// minimum TLS version settings. The PEM files are read again only when the settings change; a failure is not cached.
+// Every caller gets its own copy, which it may change.
+func getTLSConfig() (*tls.Config, error) {
+	settings := tlsSettings{
+		insecure:       viper.GetBool(commonParams.InsecureFlag),
+		caCertPath:     viper.GetString(commonParams.CACertKey),
+		clientCertPath: viper.GetString(commonParams.ClientCertKey),
+		clientKeyPath:  viper.GetString(commonParams.ClientKeyKey),
+		minTLSVersion:  strings.TrimSpace(viper.GetString(commonParams.MinTLSVersionKey)),
+	}
+	tlsConfigMutex.Lock()
+	defer tlsConfigMutex.Unlock()
+	if cachedTLSSettings == nil || *cachedTLSSettings != settings {
+		tlsConfig, err := newTLSConfig(settings)
+		if err != nil {
+			return nil, err
 		}
+		cachedTLSSettings, cachedTLSConfig = &settings, tlsConfig
+	}
+	return cachedTLSConfig.Clone(), nil
+}
+
+func newTLSConfig(settings tlsSettings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: settings.insecure} //nolint:gosec
+
+	if settings.caCertPath != "" {
+		pem, err := ioutil.ReadFile(settings.caCertPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed reading the CA certificates")
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("No PEM certificate found in %s", settings.caCertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.clientCertPath != "" || settings.clientKeyPath != "" {
+		if settings.clientCertPath == "" || settings.clientKeyPath == "" {
+			return nil, errors.Errorf(
+				"Both --%s and --%s are required for mutual TLS", commonParams.ClientCertFlag, commonParams.ClientKeyFlag,
+			)
+		}
+		cert, err := tls.LoadX509KeyPair(settings.clientCertPath, settings.clientKeyPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed loading the client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.minTLSVersion != "" {
+		version, ok := minTLSVersions[strings.TrimPrefix(strings.ToLower(settings.minTLSVersion), "tls")]
+		if !ok {
+			return nil, errors.Errorf("Invalid minimum TLS version %s, use one of 1.0, 1.1, 1.2 or 1.3", settings.minTLSVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+	return tlsConfig, nil
+}
+
+func basicProxyClient(timeout uint, router *proxyRouter, tlsConfig *tls.Config) *http.Client {
+	if router.proxy != nil || router.httpsProxy != nil || len(router.rules) > 0 {
+		logger.PrintIfVerbose("Creating HTTP Client with Proxy: " + viper.GetString(commonParams.ProxyKey))
 	} else {
 		logger.PrintIfVerbose("Creating HTTP Client.")
-		tr = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
-		}
+	}
+	tr := &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy: func(req *http.Request) (*url.URL, error) {
+			return router.proxyFor(req.URL), nil
+		},
 	}
 	return &http.Client{Transport: tr, Timeout: time.Duration(timeout) * time.Second}
 }
 
-func ntmlProxyClient(timeout uint, proxyStr string) *http.Client {
-	dialer := &net.Dialer{
+func ntmlProxyClient(timeout uint, router *proxyRouter, tlsConfig *tls.Config) *http.Client {
+	dialer := newProxyDialer()
+	logger.PrintIfVerbose("Creating HTTP client using NTLM Proxy using: " + viper.GetString(commonParams.ProxyKey))
+	return tunnelProxyClient(timeout, router, tlsConfig, dialer, newNTLMDialContext(dialer, router.proxy, tlsConfig))
+}
+
+// negotiateProxyClient authenticates to the proxy with Kerberos and falls back to NTLM when the proxy offers both
+func negotiateProxyClient(timeout uint, router *proxyRouter, tlsConfig *tls.Config) *http.Client {
+	dialer := newProxyDialer()
+	tokens := negotiate.NewKerberosTokenSource(
+		negotiate.KerberosSettings{
+			Krb5ConfPath: viper.GetString(commonParams.ProxyKrb5ConfKey),
+			KeytabPath:   viper.GetString(commonParams.ProxyKeytabKey),
+			Principal:    viper.GetString(commonParams.ProxyPrincipalKey),
+			CCachePath:   viper.GetString(commonParams.ProxyCCacheKey),
+		},
+	)
+	logger.PrintIfVerbose("Creating HTTP client using Negotiate Proxy using: " + viper.GetString(commonParams.ProxyKey))
+	negotiateDialContext := negotiate.NewNegotiateProxyDialContext(
+		dialer, router.proxy, tokens, newNTLMDialContext(dialer, router.proxy, tlsConfig), tlsConfig,
+	)
+	return tunnelProxyClient(timeout, router, tlsConfig, dialer, negotiateDialContext)
+}
+
+func newProxyDialer() *net.Dialer {
+	return &net.Dialer{
 		Timeout:   30 * time.Second,
 		KeepAlive: 30 * time.Second,
 	}
-	u, _ := url.Parse(proxyStr)
+}
+
+// newNTLMDialContext authenticates to the proxy u with NTLM, over TLS with tlsConfig when u is an https proxy
+func newNTLMDialContext(dialer *net.Dialer, u *url.URL, tlsConfig *tls.Config) ntlm.DialContext {
 	domainStr := viper.GetString(commonParams.ProxyDomainKey)
 	proxyUser := u.User.Username()
 	proxyPass, _ := u.User.Password()
-	logger.PrintIfVerbose("Creating HTTP client using NTLM Proxy using: " + proxyStr)
-	ntlmDialContext := ntlm.NewNTLMProxyDialContext(dialer, u, proxyUser, proxyPass, domainStr, nil)
+	return ntlm.NewNTLMProxyDialContext(dialer, u, proxyUser, proxyPass, domainStr, tlsConfig)
+}
+
+// tunnelProxyClient authenticates to the --proxy only, through proxyDialContext. The hosts routed elsewhere by the
+// per-host rules, NO_PROXY or HTTPS_PROXY go through a basic proxy or directly.
+func tunnelProxyClient(
+	timeout uint,
+	router *proxyRouter,
+	tlsConfig *tls.Config,
+	dialer *net.Dialer,
+	proxyDialContext ntlm.DialContext,
+) *http.Client {
 	return &http.Client{
 		Transport: &http.Transport{
-			Proxy:       nil,
-			DialContext: ntlmDialContext,
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				proxy := router.proxyFor(req.URL)
+				if proxy != nil && proxy.Host == router.proxy.Host {
+					// Tunneled by the proxy dialer
+					return nil, nil
+				}
+				return proxy, nil
+			},
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				if router.usesDefaultProxy(address) {
+					return proxyDialContext(ctx, network, address)
+				}
+				return dialer.DialContext(ctx, network, address)
+			},
+			TLSClientConfig: tlsConfig,
 		},
 		Timeout: time.Duration(timeout) * time.Second,
 	}
@@ -446,6 +603,9 @@ func getNewToken(credentialsPayload, authServerURI string) (*string, error) {
 	req.Header.Add("content-type", "application/x-www-form-urlencoded")
 	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
 	client := getClient(clientTimeout)
+	if transport, ok := client.Transport.(*failingTransport); ok {
+		return nil, transport.err
+	}
 
 	res, err := doPrivateRequest(client, req)
 	if err != nil {
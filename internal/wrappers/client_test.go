@@ -0,0 +1,251 @@
+//go:build !integration
+
+package wrappers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/spf13/viper"
+	"gotest.tools/assert"
+)
+
+// useSettings sets the viper keys for the test only
+func useSettings(t *testing.T, settings map[string]interface{}) {
+	for key, value := range settings {
+		key, previous := key, viper.Get(key)
+		viper.Set(key, value)
+		t.Cleanup(func() { viper.Set(key, previous) })
+	}
+}
+
+func TestSCMWrappersUseTheProxySetAfterTheirCreation(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				proxied = append(proxied, r.URL.Host)
+				_, _ = fmt.Fprint(w, `{"count":0,"value":[]}`)
+			},
+		),
+	)
+	defer proxy.Close()
+	azureWrapper := NewAzureWrapper()
+
+	useSettings(t, map[string]interface{}{commonParams.ProxyKey: proxy.URL})
+	_, err := azureWrapper.GetProjects("http://scm.example.com/", "org", "")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, proxied, []string{"scm.example.com"})
+}
+
+// testCA issues the certificates of the TLS tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns the PEM certificate and key of a server, or client, certificate signed by the CA
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NilError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NilError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeTestFile(t *testing.T, dir, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	assert.NilError(t, ioutil.WriteFile(path, content, 0600))
+	return path
+}
+
+// newMutualTLSServer starts a server trusting only the client certificates of ca
+func newMutualTLSServer(t *testing.T, ca *testCA) *httptest.Server {
+	certPEM, keyPEM := ca.issue(t, 2, x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	assert.NilError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server := httptest.NewUnstartedServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+			},
+		),
+	)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetClientWithMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	server := newMutualTLSServer(t, ca)
+	dir := t.TempDir()
+	caPath := writeTestFile(t, dir, "ca.pem", ca.pem)
+	clientCertPEM, clientKeyPEM := ca.issue(t, 3, x509.ExtKeyUsageClientAuth)
+	clientCertPath := writeTestFile(t, dir, "client.pem", clientCertPEM)
+	clientKeyPath := writeTestFile(t, dir, "client-key.pem", clientKeyPEM)
+
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		err      string
+	}{
+		{
+			name: "CA bundle and client certificate",
+			settings: map[string]interface{}{
+				commonParams.CACertKey:     caPath,
+				commonParams.ClientCertKey: clientCertPath,
+				commonParams.ClientKeyKey:  clientKeyPath,
+			},
+		},
+		{
+			name: "minimum TLS version",
+			settings: map[string]interface{}{
+				commonParams.CACertKey:        caPath,
+				commonParams.ClientCertKey:    clientCertPath,
+				commonParams.ClientKeyKey:     clientKeyPath,
+				commonParams.MinTLSVersionKey: "TLS1.2",
+			},
+		},
+		{
+			name:     "without client certificate",
+			settings: map[string]interface{}{commonParams.CACertKey: caPath},
+			err:      "remote error: tls",
+		},
+		{
+			name:     "without CA bundle",
+			settings: map[string]interface{}{commonParams.ClientCertKey: clientCertPath, commonParams.ClientKeyKey: clientKeyPath},
+			err:      "x509",
+		},
+		{
+			name:     "client certificate without key",
+			settings: map[string]interface{}{commonParams.CACertKey: caPath, commonParams.ClientCertKey: clientCertPath},
+			err:      "Both --client-cert and --client-key are required for mutual TLS",
+		},
+		{
+			name: "mismatched client key",
+			settings: map[string]interface{}{
+				commonParams.CACertKey:     caPath,
+				commonParams.ClientCertKey: clientCertPath,
+				commonParams.ClientKeyKey:  caPath,
+			},
+			err: "Failed loading the client certificate",
+		},
+		{
+			name:     "CA bundle without certificates",
+			settings: map[string]interface{}{commonParams.CACertKey: clientKeyPath},
+			err:      "No PEM certificate found in " + clientKeyPath,
+		},
+		{
+			name:     "invalid minimum TLS version",
+			settings: map[string]interface{}{commonParams.CACertKey: caPath, commonParams.MinTLSVersionKey: "1.4"},
+			err:      "Invalid minimum TLS version 1.4",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				settings := map[string]interface{}{
+					commonParams.CACertKey:        "",
+					commonParams.ClientCertKey:    "",
+					commonParams.ClientKeyKey:     "",
+					commonParams.MinTLSVersionKey: "",
+				}
+				for key, value := range tt.settings {
+					settings[key] = value
+				}
+				useSettings(t, settings)
+
+				// a configuration error is returned by every request, through failingTransport
+				resp, err := getClient(5).Get(server.URL)
+				if tt.err != "" {
+					assert.ErrorContains(t, err, tt.err)
+					return
+				}
+				assert.NilError(t, err)
+				defer func() {
+					_ = resp.Body.Close()
+				}()
+				body, err := ioutil.ReadAll(resp.Body)
+				assert.NilError(t, err)
+				assert.Equal(t, string(body), "127.0.0.1", "The server should see the client certificate")
+			},
+		)
+	}
+}
+
+func TestGetTLSConfigIsCachedPerSettings(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	caPath := writeTestFile(t, dir, "ca.pem", ca.pem)
+	useSettings(t, map[string]interface{}{commonParams.CACertKey: caPath, commonParams.MinTLSVersionKey: ""})
+
+	first, err := getTLSConfig()
+	assert.NilError(t, err)
+	first.InsecureSkipVerify = true
+	assert.NilError(t, os.Remove(caPath))
+	second, err := getTLSConfig()
+	assert.NilError(t, err, "The CA bundle should not be read again")
+	assert.Equal(t, second.RootCAs, first.RootCAs)
+	assert.Assert(t, !second.InsecureSkipVerify, "A change to a returned configuration should not be cached")
+
+	useSettings(t, map[string]interface{}{commonParams.MinTLSVersionKey: "1.3"})
+	_, err = getTLSConfig()
+	assert.ErrorContains(t, err, "Failed reading the CA certificates", "The CA bundle should be read again for new settings")
+}
@@ -0,0 +1,154 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const thresholdKeyParts = 2
+
+// ScanConfigFileNames are the names of the project configuration file, by order of preference
+var ScanConfigFileNames = []string{".checkmarx.yaml", ".checkmarx.yml"}
+
+var (
+	scanConfigEngines    = []string{params.SastType, params.KicsType, params.ScaType}
+	scanConfigSeverities = []string{"high", "medium", "low", "info"}
+)
+
+// ScanConfig is the project-local scan configuration, usually kept in the repository as .checkmarx.yaml
+type ScanConfig struct {
+	Project   ScanConfigProject `yaml:"project"`
+	Scan      ScanConfigScan    `yaml:"scan"`
+	Threshold map[string]int    `yaml:"threshold"`
+	Report    ScanConfigReport  `yaml:"report"`
+}
+
+type ScanConfigProject struct {
+	Name   string   `yaml:"name"`
+	Groups []string `yaml:"groups"`
+	Tags   []string `yaml:"tags"`
+}
+
+type ScanConfigScan struct {
+	Types       []string       `yaml:"types"`
+	Branch      string         `yaml:"branch"`
+	Tags        []string       `yaml:"tags"`
+	FileFilter  string         `yaml:"fileFilter"`
+	FileInclude string         `yaml:"fileInclude"`
+	Sast        ScanConfigSast `yaml:"sast"`
+	Kics        ScanConfigKics `yaml:"kics"`
+	Sca         ScanConfigSca  `yaml:"sca"`
+}
+
+type ScanConfigSast struct {
+	Filter      string `yaml:"filter"`
+	PresetName  string `yaml:"presetName"`
+	Incremental *bool  `yaml:"incremental"`
+}
+
+type ScanConfigKics struct {
+	Filter    string `yaml:"filter"`
+	Platforms string `yaml:"platforms"`
+}
+
+type ScanConfigSca struct {
+	Filter string `yaml:"filter"`
+}
+
+type ScanConfigReport struct {
+	Formats    []string `yaml:"formats"`
+	OutputName string   `yaml:"outputName"`
+	OutputPath string   `yaml:"outputPath"`
+}
+
+// FindScanConfigFile returns the path of the configuration file in the given directory or an empty string if
+// the directory does not have one
+func FindScanConfigFile(dir string) string {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return ""
+	}
+	for _, name := range ScanConfigFileNames {
+		configPath := filepath.Join(dir, name)
+		if fileInfo, statErr := os.Stat(configPath); statErr == nil && !fileInfo.IsDir() {
+			return configPath
+		}
+	}
+	return ""
+}
+
+// LoadScanConfig reads and validates a scan configuration file. Unknown keys are reported as errors.
+func LoadScanConfig(configPath string) (*ScanConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	scanConfig := &ScanConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(scanConfig)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed parsing %s", configPath)
+	}
+	err = scanConfig.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return scanConfig, nil
+}
+
+// Validate checks the values that the schema alone cannot express
+func (c *ScanConfig) Validate() error {
+	var problems []string
+	for _, scanType := range c.Scan.Types {
+		if !utils.ContainsFold(scanConfigEngines, strings.TrimSpace(scanType)) {
+			problems = append(problems, fmt.Sprintf("unknown scan type: %s", scanType))
+		}
+	}
+	thresholdKeys := make([]string, 0, len(c.Threshold))
+	for key := range c.Threshold {
+		thresholdKeys = append(thresholdKeys, key)
+	}
+	sort.Strings(thresholdKeys)
+	for _, key := range thresholdKeys {
+		limit := c.Threshold[key]
+		parts := strings.SplitN(key, "-", thresholdKeyParts)
+		if len(parts) != thresholdKeyParts ||
+			!utils.ContainsFold(scanConfigEngines, parts[0]) ||
+			!utils.ContainsFold(scanConfigSeverities, parts[1]) {
+			problems = append(problems, fmt.Sprintf("invalid threshold %s, expected <engine>-<severity>", key))
+		}
+		if limit < 1 {
+			problems = append(problems, fmt.Sprintf("invalid threshold limit for %s: %d", key, limit))
+		}
+	}
+	for _, format := range c.Report.Formats {
+		if strings.TrimSpace(format) == "" {
+			problems = append(problems, "report formats cannot be empty")
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid scan configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ThresholdString converts the threshold section to the --threshold flag format
+func (c *ScanConfig) ThresholdString() string {
+	var limits []string
+	for key, limit := range c.Threshold {
+		limits = append(limits, fmt.Sprintf("%s=%d", strings.ToLower(key), limit))
+	}
+	sort.Strings(limits)
+	return strings.Join(limits, ";")
+}
@@ -0,0 +1,99 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ScanManifest describes several projects living in the same repository that should be scanned together
+type ScanManifest struct {
+	Parallelism int                   `yaml:"parallelism"`
+	Services    []ScanManifestService `yaml:"services"`
+}
+
+type ScanManifestService struct {
+	Path          string   `yaml:"path"`
+	ProjectName   string   `yaml:"projectName"`
+	Branch        string   `yaml:"branch"`
+	ScanTypes     []string `yaml:"scanTypes"`
+	Tags          []string `yaml:"tags"`
+	ProjectTags   []string `yaml:"projectTags"`
+	ProjectGroups []string `yaml:"projectGroups"`
+}
+
+// LoadScanManifest reads and validates a scan manifest. Service paths are resolved relative to the manifest
+// location and project names default to the service directory name.
+func LoadScanManifest(manifestPath string) (*ScanManifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &ScanManifest{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(manifest)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed parsing %s", manifestPath)
+	}
+	baseDir := filepath.Dir(manifestPath)
+	for i := range manifest.Services {
+		service := &manifest.Services[i]
+		if strings.TrimSpace(service.Path) == "" {
+			continue
+		}
+		if !filepath.IsAbs(service.Path) {
+			service.Path = filepath.Join(baseDir, service.Path)
+		}
+		if strings.TrimSpace(service.ProjectName) == "" {
+			service.ProjectName = filepath.Base(service.Path)
+		}
+	}
+	err = manifest.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Validate checks that every service can be scanned
+func (m *ScanManifest) Validate() error {
+	var problems []string
+	if len(m.Services) == 0 {
+		problems = append(problems, "no services defined")
+	}
+	if m.Parallelism < 0 {
+		problems = append(problems, fmt.Sprintf("invalid parallelism: %d", m.Parallelism))
+	}
+	projectNames := make(map[string]bool)
+	for i, service := range m.Services {
+		if strings.TrimSpace(service.Path) == "" {
+			problems = append(problems, fmt.Sprintf("service %d has no path", i+1))
+			continue
+		}
+		info, err := os.Stat(service.Path)
+		if err != nil || !info.IsDir() {
+			problems = append(problems, fmt.Sprintf("service path %s is not a directory", service.Path))
+		}
+		if projectNames[service.ProjectName] {
+			problems = append(problems, fmt.Sprintf("project %s is defined more than once", service.ProjectName))
+		}
+		projectNames[service.ProjectName] = true
+		for _, scanType := range service.ScanTypes {
+			if !utils.ContainsFold(scanConfigEngines, strings.TrimSpace(scanType)) {
+				problems = append(problems, fmt.Sprintf("unknown scan type for %s: %s", service.ProjectName, scanType))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid scan manifest: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
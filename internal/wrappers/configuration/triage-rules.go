@@ -0,0 +1,104 @@
+package configuration
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers/utils"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	triageRuleEngines    = []string{params.SastType, params.KicsType, params.ScaType}
+	triageRuleStates     = []string{"TO_VERIFY", "NOT_EXPLOITABLE", "PROPOSED_NOT_EXPLOITABLE", "CONFIRMED", "URGENT"}
+	triageRuleSeverities = []string{"HIGH", "MEDIUM", "LOW", "INFO"}
+)
+
+// TriageRules are standing triage decisions applied to the results of every scan. The first matching rule wins.
+type TriageRules struct {
+	Rules []TriageRule `yaml:"rules"`
+}
+
+type TriageRule struct {
+	Name  string          `yaml:"name"`
+	Match TriageRuleMatch `yaml:"match"`
+	Set   TriageRuleSet   `yaml:"set"`
+}
+
+// TriageRuleMatch lists the conditions a result must meet, empty conditions match every result.
+// Query names, file paths and package names accept globs.
+type TriageRuleMatch struct {
+	Engine      string   `yaml:"engine"`
+	QueryName   string   `yaml:"queryName"`
+	Cwe         string   `yaml:"cwe"`
+	PathGlob    string   `yaml:"pathGlob"`
+	PackageName string   `yaml:"packageName"`
+	Severities  []string `yaml:"severities"`
+}
+
+type TriageRuleSet struct {
+	State    string `yaml:"state"`
+	Severity string `yaml:"severity"`
+	Comment  string `yaml:"comment"`
+}
+
+// LoadTriageRules reads and validates a triage rules file. Unknown keys are reported as errors.
+func LoadTriageRules(rulesPath string) (*TriageRules, error) {
+	content, err := os.ReadFile(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	rules := &TriageRules{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(rules)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrapf(err, "failed parsing %s", rulesPath)
+	}
+	for i := range rules.Rules {
+		if rules.Rules[i].Name == "" {
+			rules.Rules[i].Name = fmt.Sprintf("rule %d", i+1)
+		}
+	}
+	err = rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Validate checks that every rule can be applied
+func (r *TriageRules) Validate() error {
+	var problems []string
+	if len(r.Rules) == 0 {
+		problems = append(problems, "no rules defined")
+	}
+	for _, rule := range r.Rules {
+		if rule.Match.Engine != "" && !utils.ContainsFold(triageRuleEngines, rule.Match.Engine) {
+			problems = append(problems, fmt.Sprintf("%s: unknown engine %s", rule.Name, rule.Match.Engine))
+		}
+		for _, severity := range rule.Match.Severities {
+			if !utils.ContainsFold(triageRuleSeverities, severity) {
+				problems = append(problems, fmt.Sprintf("%s: unknown severity %s", rule.Name, severity))
+			}
+		}
+		if rule.Set.State == "" && rule.Set.Severity == "" && rule.Set.Comment == "" {
+			problems = append(problems, fmt.Sprintf("%s: set at least one of state, severity or comment", rule.Name))
+		}
+		if rule.Set.State != "" && !utils.ContainsFold(triageRuleStates, rule.Set.State) {
+			problems = append(problems, fmt.Sprintf("%s: unknown state %s", rule.Name, rule.Set.State))
+		}
+		if rule.Set.Severity != "" && !utils.ContainsFold(triageRuleSeverities, rule.Set.Severity) {
+			problems = append(problems, fmt.Sprintf("%s: unknown severity %s", rule.Name, rule.Set.Severity))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("invalid triage rules: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
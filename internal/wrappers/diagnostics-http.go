@@ -0,0 +1,216 @@
+package wrappers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	diagnosticsDialTimeout = 10 * time.Second
+	containerVersionFormat = "{{.Server.Version}}"
+)
+
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+type DiagnosticsHTTPWrapper struct{}
+
+func NewDiagnosticsHTTPWrapper() DiagnosticsWrapper {
+	return &DiagnosticsHTTPWrapper{}
+}
+
+func (d *DiagnosticsHTTPWrapper) LookupHost(host string) ([]string, error) {
+	return net.LookupHost(host)
+}
+
+func (d *DiagnosticsHTTPWrapper) Dial(address string) error {
+	conn, err := net.DialTimeout("tcp", address, diagnosticsDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (d *DiagnosticsHTTPWrapper) HandshakeTLS(address, serverName string) (*TLSDetails, error) {
+	tlsConfig, err := getTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	roots := tlsConfig.RootCAs
+	tlsConfig.ServerName = serverName
+	// The chain is verified below so that its details are reported even when it is not trusted
+	tlsConfig.InsecureSkipVerify = true
+	dialer := &net.Dialer{Timeout: diagnosticsDialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	state := conn.ConnectionState()
+	details := &TLSDetails{Version: tlsVersions[state.Version]}
+	intermediates := x509.NewCertPool()
+	for i, cert := range state.PeerCertificates {
+		details.Certificates = append(
+			details.Certificates, CertificateDetails{
+				Subject:  cert.Subject.String(),
+				Issuer:   cert.Issuer.String(),
+				NotAfter: cert.NotAfter,
+			},
+		)
+		if i > 0 {
+			intermediates.AddCert(cert)
+		}
+	}
+	if len(state.PeerCertificates) == 0 {
+		details.VerifyError = "the server sent no certificate"
+		return details, nil
+	}
+	_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{DNSName: serverName, Roots: roots, Intermediates: intermediates})
+	if err != nil {
+		details.VerifyError = err.Error()
+	}
+	return details, nil
+}
+
+func (d *DiagnosticsHTTPWrapper) ConnectThroughProxy(rawURL string) error {
+	route, err := ResolveProxy(rawURL)
+	if err != nil {
+		return err
+	}
+	if route == nil {
+		return errors.Errorf("%s is not reached through a proxy", rawURL)
+	}
+	u, _ := url.Parse(rawURL)
+	address := proxyAddress(&url.URL{Scheme: u.Scheme, Host: u.Host})
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsDialTimeout)
+	defer cancel()
+
+	var conn net.Conn
+	if route.Type == basicProxyType {
+		tlsConfig, tlsErr := getTLSConfig()
+		if tlsErr != nil {
+			return tlsErr
+		}
+		conn, err = connectThroughBasicProxy(ctx, route.URL, address, tlsConfig)
+	} else {
+		// The client tunnels the connections to the hosts of --proxy with its authenticating dialer
+		client := getClient(viper.GetUint(commonParams.ClientTimeoutKey))
+		transport, ok := client.Transport.(*http.Transport)
+		if !ok {
+			_, err = client.Transport.RoundTrip(nil)
+			return err
+		}
+		conn, err = transport.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// connectThroughBasicProxy sends a CONNECT request for address to the proxy, with its user and password if any
+func connectThroughBasicProxy(ctx context.Context, proxyURL *url.URL, address string, tlsConfig *tls.Config) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: diagnosticsDialTimeout}
+	var conn net.Conn
+	var err error
+	if proxyURL.Scheme == httpsScheme {
+		conn, err = tls.DialWithDialer(dialer, "tcp", proxyAddress(proxyURL), tlsConfig)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", proxyAddress(proxyURL))
+	}
+	if err != nil {
+		return nil, err
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if err = req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, errors.Errorf("The proxy answered %s", resp.Status)
+	}
+	return conn, nil
+}
+
+func (d *DiagnosticsHTTPWrapper) Get(url string) (int, error) {
+	resp, err := SendHTTPRequestByFullURL(http.MethodGet, url, nil, false, viper.GetUint(commonParams.ClientTimeoutKey))
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (d *DiagnosticsHTTPWrapper) GetAccessToken() (string, error) {
+	authURI, err := getAuthURI()
+	if err != nil {
+		return "", err
+	}
+	accessKeyID := viper.GetString(commonParams.AccessKeyIDConfigKey)
+	accessKeySecret := viper.GetString(commonParams.AccessKeySecretConfigKey)
+	astAPIKey := viper.GetString(commonParams.AstAPIKey)
+	var accessToken *string
+	switch {
+	case astAPIKey != "":
+		accessToken, err = getNewToken(getAPIKeyPayload(astAPIKey), authURI)
+	case accessKeyID != "" && accessKeySecret != "":
+		accessToken, err = getNewToken(getCredentialsPayload(accessKeyID, accessKeySecret), authURI)
+	default:
+		return "", errors.New("no API key nor client ID and secret are configured")
+	}
+	if err != nil {
+		return "", err
+	}
+	return *accessToken, nil
+}
+
+func (d *DiagnosticsHTTPWrapper) GetContainerEngineVersion(engine string) (string, error) {
+	out, err := exec.Command(engine, "version", "--format", containerVersionFormat).CombinedOutput()
+	if err != nil {
+		if message := strings.TrimSpace(string(out)); message != "" {
+			return "", errors.Errorf("%s: %s", err, message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
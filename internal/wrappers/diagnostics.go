@@ -0,0 +1,34 @@
+package wrappers
+
+import "time"
+
+type CertificateDetails struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+type TLSDetails struct {
+	Version      string               `json:"version"`
+	Certificates []CertificateDetails `json:"certificates"`
+	// VerifyError is the reason the certificate chain is not trusted, empty when it is trusted
+	VerifyError string `json:"verifyError,omitempty"`
+}
+
+type DiagnosticsWrapper interface {
+	// LookupHost resolves the host addresses
+	LookupHost(host string) ([]string, error)
+	// Dial opens and closes a TCP connection to the host:port address
+	Dial(address string) error
+	// HandshakeTLS connects to the host:port address and returns the details of the TLS session
+	HandshakeTLS(address, serverName string) (*TLSDetails, error)
+	// ConnectThroughProxy opens, then closes, a tunnel to the host of the URL through the proxy the clients use for it,
+	// authenticating like they do
+	ConnectThroughProxy(url string) error
+	// Get requests the URL with the configured client, through the proxy when there is one, and returns the status code
+	Get(url string) (int, error)
+	// GetAccessToken requests a new access token with the configured credentials
+	GetAccessToken() (string, error)
+	// GetContainerEngineVersion returns the server version of the container engine
+	GetContainerEngineVersion(engine string) (string, error)
+}
@@ -15,7 +15,6 @@ import (
 )
 
 type GitHubHTTPWrapper struct {
-	client               *http.Client
 	repositoryTemplate   string
 	organizationTemplate string
 }
@@ -36,9 +35,7 @@ const (
 )
 
 func NewGitHubWrapper() GitHubWrapper {
-	return &GitHubHTTPWrapper{
-		client: getClient(viper.GetUint(params.ClientTimeoutKey)),
-	}
+	return &GitHubHTTPWrapper{}
 }
 
 func (g *GitHubHTTPWrapper) GetOrganization(organizationName string) (Organization, error) {
@@ -75,7 +72,7 @@ func (g *GitHubHTTPWrapper) GetRepository(organizationName, repositoryName strin
 func (g *GitHubHTTPWrapper) GetRepositories(organization Organization) ([]Repository, error) {
 	repositoriesURL := organization.RepositoriesURL
 
-	pages, err := getWithPagination(g.client, repositoriesURL, map[string]string{})
+	pages, err := getWithPagination(getClient(viper.GetUint(params.ClientTimeoutKey)), repositoriesURL, map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +102,7 @@ func (g *GitHubHTTPWrapper) GetCommits(repository Repository, queryParams map[st
 	}
 	commitsURL = commitsURL[:index]
 
-	pages, err := getWithPagination(g.client, commitsURL, queryParams)
+	pages, err := getWithPagination(getClient(viper.GetUint(params.ClientTimeoutKey)), commitsURL, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +158,7 @@ func (g *GitHubHTTPWrapper) getTemplates() error {
 }
 
 func (g *GitHubHTTPWrapper) get(url string, target interface{}) error {
-	resp, err := get(g.client, url, target, map[string]string{})
+	resp, err := get(getClient(viper.GetUint(params.ClientTimeoutKey)), url, target, map[string]string{})
 
 	closeBody(resp)
 
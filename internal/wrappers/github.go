@@ -10,8 +10,11 @@ type Organization struct {
 }
 
 type Repository struct {
-	FullName   string `json:"full_name"`
-	CommitsURL string `json:"commits_url"`
+	ID            int64  `json:"id"`
+	FullName      string `json:"full_name"`
+	CommitsURL    string `json:"commits_url"`
+	CloneURL      string `json:"clone_url"`
+	DefaultBranch string `json:"default_branch"`
 }
 
 type CommitRoot struct {
@@ -15,7 +15,6 @@ import (
 )
 
 type GitLabHTTPWrapper struct {
-	client *http.Client
 }
 
 const (
@@ -33,9 +32,7 @@ const (
 )
 
 func NewGitLabWrapper() GitLabWrapper {
-	return &GitLabHTTPWrapper{
-		client: getClient(viper.GetUint(params.ClientTimeoutKey)),
-	}
+	return &GitLabHTTPWrapper{}
 }
 
 func (g *GitLabHTTPWrapper) GetGitLabProjectsForUser() ([]GitLabProject, error) {
@@ -43,7 +40,7 @@ func (g *GitLabHTTPWrapper) GetGitLabProjectsForUser() ([]GitLabProject, error)
 	gitLabBaseURL := viper.GetString(params.GitLabURLFlag)
 	getUserProjectsURL := fmt.Sprintf(gitLabProjectsURL, gitLabBaseURL, gitLabAPIVersion)
 
-	pages, err := fetchWithPagination(g.client, getUserProjectsURL, map[string]string{})
+	pages, err := fetchWithPagination(getClient(viper.GetUint(params.ClientTimeoutKey)), getUserProjectsURL, map[string]string{})
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +71,7 @@ func (g *GitLabHTTPWrapper) GetCommits(
 
 	logger.PrintIfVerbose(fmt.Sprintf("Getting commits for project: %s", gitLabProjectPathWithNameSpace))
 
-	pages, err := fetchWithPagination(g.client, commitsURL, queryParams)
+	pages, err := fetchWithPagination(getClient(viper.GetUint(params.ClientTimeoutKey)), commitsURL, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +102,7 @@ func (g *GitLabHTTPWrapper) GetGitLabProjects(gitLabGroupName string, queryParam
 	logger.PrintIfVerbose(fmt.Sprintf("Finding the projects for group: %s", gitLabGroupName))
 	projectsURL := fmt.Sprintf(gitLabGroupProjectsURL, gitLabBaseURL, gitLabAPIVersion, encodedGroupName)
 
-	pages, err := fetchWithPagination(g.client, projectsURL, queryParams)
+	pages, err := fetchWithPagination(getClient(viper.GetUint(params.ClientTimeoutKey)), projectsURL, queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -14,6 +14,8 @@ type GitLabProject struct {
 	Visibility        string `json:"visibility"`
 	EmptyRepo         bool   `json:"empty_repo"`
 	RepoAccessLevel   string `json:"repository_access_level"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	DefaultBranch     string `json:"default_branch"`
 }
 
 type GitLabUser struct {
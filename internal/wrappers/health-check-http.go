@@ -0,0 +1,77 @@
+package wrappers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	failedToParseHealthcheck = "Failed to parse the healthcheck response"
+)
+
+type HealthCheckHTTPWrapper struct {
+	path               string
+	webAppPath         string
+	keycloakWebAppPath string
+}
+
+func NewHTTPHealthCheckWrapper(path, webAppPath, keycloakWebAppPath string) HealthCheckWrapper {
+	return &HealthCheckHTTPWrapper{
+		path:               path,
+		webAppPath:         webAppPath,
+		keycloakWebAppPath: keycloakWebAppPath,
+	}
+}
+
+func (h *HealthCheckHTTPWrapper) CheckWebApp() error {
+	return checkPage(GetURL(h.webAppPath))
+}
+
+func (h *HealthCheckHTTPWrapper) CheckKeycloakWebApp() error {
+	return checkPage(GetAuthURL(h.keycloakWebAppPath))
+}
+
+func (h *HealthCheckHTTPWrapper) CheckSubsystem(subsystemPath string) (*HealthcheckModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequest(http.MethodGet, h.path+"/"+subsystemPath, nil, true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusServiceUnavailable, http.StatusInternalServerError:
+		model := HealthcheckModel{}
+		err = json.NewDecoder(resp.Body).Decode(&model)
+		if err != nil {
+			if resp.StatusCode != http.StatusOK {
+				return nil, errors.Errorf("Response status code %d", resp.StatusCode)
+			}
+			return nil, errors.Wrapf(err, failedToParseHealthcheck)
+		}
+		return &model, nil
+	default:
+		return nil, errors.Errorf("Response status code %d", resp.StatusCode)
+	}
+}
+
+func checkPage(url string) error {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	resp, err := SendHTTPRequestByFullURL(http.MethodGet, url, nil, false, clientTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("Response status code %d", resp.StatusCode)
+	}
+	return nil
+}
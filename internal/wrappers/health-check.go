@@ -0,0 +1,15 @@
+package wrappers
+
+type HealthcheckModel struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type HealthCheckWrapper interface {
+	// CheckWebApp requests the web application page
+	CheckWebApp() error
+	// CheckKeycloakWebApp requests the Keycloak web application page
+	CheckKeycloakWebApp() error
+	// CheckSubsystem requests the healthcheck endpoint of the subsystem
+	CheckSubsystem(subsystemPath string) (*HealthcheckModel, error)
+}
@@ -0,0 +1,66 @@
+package wrappers
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"os/exec"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/google/shlex"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	contentTypeHeader   = "Content-Type"
+	hookJSONContentType = "application/json"
+)
+
+type HooksHTTPWrapper struct{}
+
+func NewHTTPHooksWrapper() HooksWrapper {
+	return &HooksHTTPWrapper{}
+}
+
+func (h *HooksHTTPWrapper) Post(url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	setAgentName(req)
+	req.Header.Set(contentTypeHeader, hookJSONContentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	client := getClient(viper.GetUint(commonParams.ClientTimeoutKey))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("Response status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (h *HooksHTTPWrapper) Run(command string, body []byte, env []string) error {
+	args, err := shlex.Split(command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("empty hook command")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(), env...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "%s", bytes.TrimSpace(output))
+	}
+	return nil
+}
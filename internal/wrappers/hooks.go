@@ -0,0 +1,35 @@
+package wrappers
+
+import "time"
+
+const (
+	HookScanCreated         = "scan.created"
+	HookScanCompleted       = "scan.completed"
+	HookScanFailed          = "scan.failed"
+	HookScanPartial         = "scan.partial"
+	HookScanThresholdFailed = "scan.threshold_failed"
+)
+
+// HookEvents are all the scan lifecycle events that can trigger a hook
+var HookEvents = []string{
+	HookScanCreated,
+	HookScanCompleted,
+	HookScanFailed,
+	HookScanPartial,
+	HookScanThresholdFailed,
+}
+
+type HookPayload struct {
+	Event     string             `json:"event"`
+	Timestamp time.Time          `json:"timestamp"`
+	Scan      *ScanResponseModel `json:"scan"`
+	Summary   *ResultSummary     `json:"summary,omitempty"`
+	Message   string             `json:"message,omitempty"`
+}
+
+type HooksWrapper interface {
+	// Post sends the JSON body to the URL
+	Post(url string, body []byte, headers map[string]string) error
+	// Run executes the command with the body on the standard input and the extra environment variables
+	Run(command string, body []byte, env []string) error
+}
@@ -0,0 +1,139 @@
+package wrappers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/logger"
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+)
+
+const (
+	learnMoreBatchSize       = 50
+	learnMoreCacheExtension  = ".json"
+	learnMoreCacheDirMode    = 0700
+	learnMoreCacheFileMode   = 0600
+	failedCachingDescription = "Failed caching the description of query %s"
+)
+
+type learnMoreCacheEntry struct {
+	FetchedAt   time.Time          `json:"fetchedAt"`
+	Description *LearnMoreResponse `json:"description"`
+}
+
+// CachedLearnMoreWrapper is a LearnMoreWrapper that keeps the query descriptions on disk for ttl. The query ids
+// missing from the cache are fetched in batches. When offline, only the cache is used, however old.
+type CachedLearnMoreWrapper struct {
+	LearnMoreWrapper
+	dir     string
+	ttl     time.Duration
+	offline bool
+}
+
+func NewCachedLearnMoreWrapper(wrapper LearnMoreWrapper, dir string, ttl time.Duration, offline bool) LearnMoreWrapper {
+	return &CachedLearnMoreWrapper{LearnMoreWrapper: wrapper, dir: dir, ttl: ttl, offline: offline}
+}
+
+// GetLearnMoreDetails returns the descriptions of the comma separated query ids, in their order
+func (w *CachedLearnMoreWrapper) GetLearnMoreDetails(params map[string]string) (*[]*LearnMoreResponse, *WebError, error) {
+	queryIDs := DistinctQueryIDs(strings.Split(params[commonParams.IDsQueryParam], ","))
+	descriptions := make(map[string]*LearnMoreResponse, len(queryIDs))
+	var missing []string
+	for _, queryID := range queryIDs {
+		entry := w.read(queryID)
+		if entry != nil && (w.offline || time.Since(entry.FetchedAt) < w.ttl) {
+			descriptions[queryID] = entry.Description
+		} else {
+			missing = append(missing, queryID)
+		}
+	}
+
+	if !w.offline {
+		for start := 0; start < len(missing); start += learnMoreBatchSize {
+			end := start + learnMoreBatchSize
+			if end > len(missing) {
+				end = len(missing)
+			}
+			batchParams := make(map[string]string, len(params))
+			for key, value := range params {
+				batchParams[key] = value
+			}
+			batchParams[commonParams.IDsQueryParam] = strings.Join(missing[start:end], ",")
+			fetched, webError, err := w.LearnMoreWrapper.GetLearnMoreDetails(batchParams)
+			if err != nil || webError != nil {
+				return nil, webError, err
+			}
+			if fetched == nil {
+				continue
+			}
+			for _, description := range *fetched {
+				if description == nil {
+					continue
+				}
+				descriptions[description.QueryID] = description
+				if err = w.write(description); err != nil {
+					logger.Printf("Warning: %s", err)
+				}
+			}
+		}
+	}
+
+	found := []*LearnMoreResponse{}
+	for _, queryID := range queryIDs {
+		if description, ok := descriptions[queryID]; ok {
+			found = append(found, description)
+		}
+	}
+	return &found, nil, nil
+}
+
+func (w *CachedLearnMoreWrapper) path(queryID string) string {
+	return filepath.Join(w.dir, url.PathEscape(queryID)+learnMoreCacheExtension)
+}
+
+// read returns the cached entry of the query, nil when it is missing or unreadable
+func (w *CachedLearnMoreWrapper) read(queryID string) *learnMoreCacheEntry {
+	data, err := ioutil.ReadFile(w.path(queryID))
+	if err != nil {
+		return nil
+	}
+	entry := &learnMoreCacheEntry{}
+	if err = json.Unmarshal(data, entry); err != nil || entry.Description == nil {
+		return nil
+	}
+	return entry
+}
+
+func (w *CachedLearnMoreWrapper) write(description *LearnMoreResponse) error {
+	if err := os.MkdirAll(w.dir, learnMoreCacheDirMode); err != nil {
+		return errors.Wrapf(err, failedCachingDescription, description.QueryID)
+	}
+	data, err := json.Marshal(learnMoreCacheEntry{FetchedAt: time.Now(), Description: description})
+	if err != nil {
+		return errors.Wrapf(err, failedCachingDescription, description.QueryID)
+	}
+	if err = ioutil.WriteFile(w.path(description.QueryID), data, learnMoreCacheFileMode); err != nil {
+		return errors.Wrapf(err, failedCachingDescription, description.QueryID)
+	}
+	return nil
+}
+
+// DistinctQueryIDs returns the query ids without blanks and duplicates, in their order
+func DistinctQueryIDs(queryIDs []string) []string {
+	var distinct []string
+	seen := make(map[string]bool)
+	for _, queryID := range queryIDs {
+		queryID = strings.TrimSpace(queryID)
+		if queryID != "" && !seen[queryID] {
+			seen[queryID] = true
+			distinct = append(distinct, queryID)
+		}
+	}
+	return distinct
+}
@@ -34,7 +34,10 @@ func (g AzureMockWrapper) GetRepositories(url, organizationName, projectName, to
 	if len(projectName) > 0 {
 		var repos = make([]wrappers.AzureRepo, 1)
 		repos[0] = wrappers.AzureRepo{
-			Name: "MOCK REPO",
+			ID:            "MOCK",
+			Name:          "MOCK REPO",
+			RemoteURL:     "https://dev.azure.com/MOCK/MOCK/_git/MOCK%20REPO",
+			DefaultBranch: "refs/heads/main",
 		}
 		return wrappers.AzureRootRepo{Repos: repos}, nil
 	}
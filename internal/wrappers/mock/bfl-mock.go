@@ -1,10 +1,14 @@
 package mock
 
 import (
+	"sync/atomic"
+
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 )
 
 type BflMockWrapper struct {
+	// Requests counts the calls when set
+	Requests *int32
 }
 
 func (bfl *BflMockWrapper) GetBflByScanIDAndQueryID(params map[string]string) (
@@ -13,6 +17,9 @@ func (bfl *BflMockWrapper) GetBflByScanIDAndQueryID(params map[string]string) (
 	error,
 ) {
 	const mock = "MOCK"
+	if bfl.Requests != nil {
+		atomic.AddInt32(bfl.Requests, 1)
+	}
 	return &wrappers.BFLResponseModel{
 		ID: mock,
 		Trees: []wrappers.BFLTreeModel{
@@ -28,7 +35,14 @@ func (bfl *BflMockWrapper) GetBflByScanIDAndQueryID(params map[string]string) (
 					Name:       mock,
 					DomType:    mock,
 				},
-				Results: nil,
+				Results: []*wrappers.ScanResultData{
+					{ResultHash: "MOCK-HASH"},
+				},
+				Nodes: map[string]*wrappers.ScanResultNode{
+					"1": {FileName: mock, Line: 1, Method: mock, Name: "input"},
+					"2": {FileName: mock, Line: 0, Column: 0, Method: mock, Name: mock},
+				},
+				NodesAdjacencyPairs: [][]string{{"1", "2"}},
 			},
 		},
 		TotalCount: 1,
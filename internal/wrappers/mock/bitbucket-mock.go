@@ -34,6 +34,13 @@ func (g BitBucketMockWrapper) GetRepositories(bitBucketURL, workspaceUUID, bitBu
 		repos[0] = wrappers.BitBucketRepo{
 			Name: "MOCK REPO",
 			UUID: "{MOCK UUID}",
+			Links: wrappers.BitBucketRepoLinks{
+				Clone: []wrappers.BitBucketLink{
+					{Name: "https", Href: "https://bitbucket.org/MOCK/mock-repo.git"},
+					{Name: "ssh", Href: "git@bitbucket.org:MOCK/mock-repo.git"},
+				},
+			},
+			MainBranch: wrappers.BitBucketMainBranch{Name: "main"},
 		}
 		return wrappers.BitBucketRootRepoList{Values: repos}, nil
 	}
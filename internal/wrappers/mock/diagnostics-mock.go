@@ -0,0 +1,66 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
+)
+
+type DiagnosticsMockWrapper struct {
+	// NoContainerEngine makes the container engine unavailable
+	NoContainerEngine bool
+	// ProxyRefused makes the proxy refuse the tunnels
+	ProxyRefused bool
+}
+
+func (d *DiagnosticsMockWrapper) LookupHost(host string) ([]string, error) {
+	fmt.Println("Called LookupHost in DiagnosticsMockWrapper")
+	if host == "" {
+		return nil, errors.New("no such host")
+	}
+	return []string{"127.0.0.1"}, nil
+}
+
+func (d *DiagnosticsMockWrapper) Dial(_ string) error {
+	fmt.Println("Called Dial in DiagnosticsMockWrapper")
+	return nil
+}
+
+func (d *DiagnosticsMockWrapper) HandshakeTLS(_, serverName string) (*wrappers.TLSDetails, error) {
+	fmt.Println("Called HandshakeTLS in DiagnosticsMockWrapper")
+	return &wrappers.TLSDetails{
+		Version: "TLS 1.3",
+		Certificates: []wrappers.CertificateDetails{
+			{Subject: "CN=" + serverName, Issuer: "CN=MOCK CA", NotAfter: time.Now().AddDate(1, 0, 0)},
+		},
+	}, nil
+}
+
+func (d *DiagnosticsMockWrapper) ConnectThroughProxy(_ string) error {
+	fmt.Println("Called ConnectThroughProxy in DiagnosticsMockWrapper")
+	if d.ProxyRefused {
+		return errors.New("The proxy answered 407 Proxy Authentication Required")
+	}
+	return nil
+}
+
+func (d *DiagnosticsMockWrapper) Get(_ string) (int, error) {
+	fmt.Println("Called Get in DiagnosticsMockWrapper")
+	return http.StatusOK, nil
+}
+
+func (d *DiagnosticsMockWrapper) GetAccessToken() (string, error) {
+	fmt.Println("Called GetAccessToken in DiagnosticsMockWrapper")
+	return "MOCK-TOKEN", nil
+}
+
+func (d *DiagnosticsMockWrapper) GetContainerEngineVersion(engine string) (string, error) {
+	fmt.Println("Called GetContainerEngineVersion in DiagnosticsMockWrapper")
+	if d.NoContainerEngine {
+		return "", errors.Errorf("exec: %q: executable file not found in $PATH", engine)
+	}
+	return "20.10.17", nil
+}
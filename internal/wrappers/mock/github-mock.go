@@ -16,7 +16,20 @@ func (g GitHubMockWrapper) GetRepository(string, string) (wrappers.Repository, e
 }
 
 func (g GitHubMockWrapper) GetRepositories(wrappers.Organization) ([]wrappers.Repository, error) {
-	return []wrappers.Repository{{}}, nil
+	return []wrappers.Repository{
+		{
+			ID:            1,
+			FullName:      "MOCK",
+			CloneURL:      "https://github.com/MOCK/MOCK.git",
+			DefaultBranch: "main",
+		},
+		{
+			ID:            2,
+			FullName:      "MOCK/new-repo",
+			CloneURL:      "https://github.com/MOCK/new-repo.git",
+			DefaultBranch: "main",
+		},
+	}, nil
 }
 
 func (g GitHubMockWrapper) GetCommits(wrappers.Repository, map[string]string) ([]wrappers.CommitRoot, error) {
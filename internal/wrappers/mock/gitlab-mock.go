@@ -14,7 +14,15 @@ func (g GitLabMockWrapper) GetGitLabProjectsForUser() ([]wrappers.GitLabProject,
 func (g GitLabMockWrapper) GetGitLabProjects(
 	gitLabGroupName string, queryParams map[string]string,
 ) ([]wrappers.GitLabProject, error) {
-	return []wrappers.GitLabProject{}, nil
+	return []wrappers.GitLabProject{
+		{
+			ID:                1,
+			Name:              "new-repo",
+			PathWithNameSpace: gitLabGroupName + "/new-repo",
+			HTTPURLToRepo:     "https://gitlab.com/" + gitLabGroupName + "/new-repo.git",
+			DefaultBranch:     "main",
+		},
+	}, nil
 }
 
 func (g GitLabMockWrapper) GetCommits(
@@ -0,0 +1,30 @@
+package mock
+
+import (
+	"fmt"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
+
+type HealthCheckMockWrapper struct {
+	// FailingSubsystem is the subsystem path reported as unhealthy
+	FailingSubsystem string
+}
+
+func (h *HealthCheckMockWrapper) CheckWebApp() error {
+	fmt.Println("Called CheckWebApp in HealthCheckMockWrapper")
+	return nil
+}
+
+func (h *HealthCheckMockWrapper) CheckKeycloakWebApp() error {
+	fmt.Println("Called CheckKeycloakWebApp in HealthCheckMockWrapper")
+	return nil
+}
+
+func (h *HealthCheckMockWrapper) CheckSubsystem(subsystemPath string) (*wrappers.HealthcheckModel, error) {
+	fmt.Println("Called CheckSubsystem in HealthCheckMockWrapper")
+	if h.FailingSubsystem != "" && subsystemPath == h.FailingSubsystem {
+		return &wrappers.HealthcheckModel{Success: false, Message: "MOCK is down"}, nil
+	}
+	return &wrappers.HealthcheckModel{Success: true}, nil
+}
@@ -0,0 +1,50 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const failingHook = "fail"
+
+type HooksMockWrapper struct {
+	Calls int
+	// Deliveries records every Post and Run, in order
+	Deliveries []HookDelivery
+	lock       sync.Mutex
+}
+
+type HookDelivery struct {
+	Target  string
+	Body    []byte
+	Headers map[string]string
+	Env     []string
+}
+
+func (h *HooksMockWrapper) Post(url string, body []byte, headers map[string]string) error {
+	fmt.Println("Called Post in HooksMockWrapper with", url, headers)
+	h.record(HookDelivery{Target: url, Body: body, Headers: headers})
+	if strings.Contains(url, failingHook) {
+		return errors.New("mock hook failure")
+	}
+	return nil
+}
+
+func (h *HooksMockWrapper) Run(command string, body []byte, env []string) error {
+	fmt.Println("Called Run in HooksMockWrapper with", command, env)
+	h.record(HookDelivery{Target: command, Body: body, Env: env})
+	if strings.Contains(command, failingHook) {
+		return errors.New("mock hook failure")
+	}
+	return nil
+}
+
+func (h *HooksMockWrapper) record(delivery HookDelivery) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.Calls++
+	h.Deliveries = append(h.Deliveries, delivery)
+}
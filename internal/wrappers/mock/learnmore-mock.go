@@ -1,28 +1,46 @@
 package mock
 
-import "github.com/checkmarx/ast-cli/internal/wrappers"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/checkmarx/ast-cli/internal/params"
+	"github.com/checkmarx/ast-cli/internal/wrappers"
+)
 
 type LearnMoreMockWrapper struct {
+	// Requests counts the calls when set
+	Requests *int
 }
 
 func (l LearnMoreMockWrapper) GetLearnMoreDetails(m map[string]string) (*[]*wrappers.LearnMoreResponse, *wrappers.WebError, error) {
+	fmt.Println("Called GetLearnMoreDetails in LearnMoreMockWrapper")
+	if l.Requests != nil {
+		*l.Requests++
+	}
+	responses := []*wrappers.LearnMoreResponse{}
+	for _, queryID := range strings.Split(m[params.IDsQueryParam], ",") {
+		responses = append(responses, learnMoreMockResponse(queryID))
+	}
+	return &responses, nil, nil
+}
+
+func learnMoreMockResponse(queryID string) *wrappers.LearnMoreResponse {
 	const mock = "MOCK"
-	return &[]*wrappers.LearnMoreResponse{
-		{
-			QueryID:                mock,
-			QueryName:              mock,
-			QueryDescriptionID:     mock,
-			ResultDescription:      mock,
-			Risk:                   mock,
-			Cause:                  mock,
-			GeneralRecommendations: mock,
-			Samples: []wrappers.SampleObject{
-				{
-					ProgLanguage: mock,
-					Code:         mock,
-					Title:        mock,
-				},
+	return &wrappers.LearnMoreResponse{
+		QueryID:                queryID,
+		QueryName:              mock,
+		QueryDescriptionID:     mock,
+		ResultDescription:      mock,
+		Risk:                   mock,
+		Cause:                  mock,
+		GeneralRecommendations: mock,
+		Samples: []wrappers.SampleObject{
+			{
+				ProgLanguage: mock,
+				Code:         mock,
+				Title:        mock,
 			},
 		},
-	}, nil, nil
+	}
 }
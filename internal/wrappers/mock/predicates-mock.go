@@ -2,9 +2,11 @@ package mock
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/checkmarx/ast-cli/internal/wrappers"
+	"github.com/pkg/errors"
 )
 
 type ResultsPredicatesMockWrapper struct {
@@ -14,6 +16,9 @@ func (r ResultsPredicatesMockWrapper) PredicateSeverityAndState(predicate *wrapp
 	*wrappers.WebError, error,
 ) {
 	fmt.Println("Called 'PredicateSeverityAndState' in ResultsPredicatesMockWrapper")
+	if strings.Contains(predicate.SimilarityID, "fail") {
+		return nil, errors.New("mock predicate failure")
+	}
 	return nil, nil
 }
 
@@ -25,6 +30,13 @@ func (r ResultsPredicatesMockWrapper) GetAllPredicatesForSimilarityID(similarity
 	totalCount := 1
 
 	mockPredicateItem := wrappers.Predicate{
+		BasePredicate: wrappers.BasePredicate{
+			SimilarityID: similarityID,
+			ProjectID:    projectID,
+			State:        "CONFIRMED",
+			Severity:     "HIGH",
+			Comment:      "MOCK",
+		},
 		ID:        "MOCK",
 		CreatedBy: "MOCK",
 		CreatedAt: time.Now(),
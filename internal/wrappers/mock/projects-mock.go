@@ -18,11 +18,75 @@ func (p *ProjectsMockWrapper) Create(model *wrappers.Project) (
 	}, nil, nil
 }
 
+func (p *ProjectsMockWrapper) Update(projectID string, model *wrappers.Project) (*wrappers.ErrorModel, error) {
+	fmt.Println("Called Update for project", projectID, "in ProjectsMockWrapper with", *model)
+	return nil, nil
+}
+
 func (p *ProjectsMockWrapper) UpdateConfiguration(projectID string, configuration []wrappers.ProjectConfiguration) (*wrappers.ErrorModel, error) {
 	fmt.Println("Called Update Configuration for project", projectID, " in ProjectsMockWrapper with the configuration ", configuration)
 	return nil, nil
 }
 
+func (p *ProjectsMockWrapper) GetConfiguration(projectID string) (
+	[]wrappers.ProjectConfiguration,
+	*wrappers.ErrorModel,
+	error) {
+	fmt.Println("Called GetConfiguration for project", projectID, "in ProjectsMockWrapper")
+	return []wrappers.ProjectConfiguration{
+		{
+			Key:           "scan.handler.git.repository",
+			Name:          "repository",
+			Category:      "git",
+			OriginLevel:   "Project",
+			Value:         "https://github.com/dummyuser/dummy_project.git",
+			ValueType:     "String",
+			AllowOverride: true,
+		},
+		{
+			Key:           "scan.handler.git.sshKey",
+			Name:          "sshKey",
+			Category:      "git",
+			OriginLevel:   "Project",
+			Value:         "MOCK-SSH-KEY",
+			ValueType:     "Secret",
+			AllowOverride: true,
+		},
+		{
+			Key:           "scan.config.sast.incremental",
+			Name:          "incremental",
+			Category:      "sast",
+			OriginLevel:   "Tenant",
+			Value:         "false",
+			ValueType:     "Bool",
+			AllowOverride: true,
+		},
+		{
+			Key:           "scan.config.sast.presetName",
+			Name:          "presetName",
+			Category:      "sast",
+			OriginLevel:   "Tenant",
+			Value:         "Checkmarx Default",
+			ValueType:     "List",
+			AllowOverride: true,
+		},
+		{
+			Key:           "scan.config.sast.engineVerbose",
+			Name:          "engineVerbose",
+			Category:      "sast",
+			OriginLevel:   "Tenant",
+			Value:         "false",
+			ValueType:     "Bool",
+			AllowOverride: false,
+		},
+	}, nil, nil
+}
+
+func (p *ProjectsMockWrapper) DeleteConfiguration(projectID string, keys []string) (*wrappers.ErrorModel, error) {
+	fmt.Println("Called DeleteConfiguration for project", projectID, "in ProjectsMockWrapper with the keys", keys)
+	return nil, nil
+}
+
 func (p *ProjectsMockWrapper) Get(params map[string]string) (
 	*wrappers.ProjectsCollectionResponseModel,
 	*wrappers.ErrorModel,
@@ -1,6 +1,8 @@
 package mock
 
 import (
+	"encoding/json"
+
 	"github.com/checkmarx/ast-cli/internal/wrappers"
 )
 
@@ -40,9 +42,19 @@ func (r ResultsMockWrapper) GetAllResultsByScanID(_ map[string]string) (
 		TotalCount: 3,
 		Results: []*wrappers.ScanResult{
 			{
-				Type:     "sast",
-				Severity: "high",
+				Type:         "sast",
+				ID:           "MOCK-SAST-ID",
+				SimilarityID: "MOCK-SAST",
+				State:        "TO_VERIFY",
+				Severity:     "high",
+				VulnerabilityDetails: wrappers.VulnerabilityDetails{
+					CweID: 79,
+				},
 				ScanResultData: wrappers.ScanResultData{
+					QueryID:      json.Number("5157925289005576664"),
+					QueryName:    "Reflected_XSS_All_Clients",
+					LanguageName: "PHP",
+					ResultHash:   "MOCK-HASH",
 					Nodes: []*wrappers.ScanResultNode{
 						{
 							FileName: "dummy-file-name",
@@ -60,8 +72,9 @@ func (r ResultsMockWrapper) GetAllResultsByScanID(_ map[string]string) (
 				},
 			},
 			{
-				Type:     "sca",
-				Severity: "medium",
+				Type:         "sca",
+				SimilarityID: "MOCK-SCA",
+				Severity:     "medium",
 				ScanResultData: wrappers.ScanResultData{
 					ScaPackageCollection: &wrappers.ScaPackageCollection{
 						ID:                  "mock",
@@ -90,8 +103,13 @@ func (r ResultsMockWrapper) GetAllResultsByScanID(_ map[string]string) (
 				},
 			},
 			{
-				Type:     "kics",
-				Severity: "low",
+				Type:         "kics",
+				SimilarityID: "MOCK-KICS",
+				State:        "TO_VERIFY",
+				Severity:     "low",
+				ScanResultData: wrappers.ScanResultData{
+					Filename: "/test/main.tf",
+				},
 			},
 		},
 	}, nil, nil
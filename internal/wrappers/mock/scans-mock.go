@@ -10,6 +10,8 @@ import (
 
 type ScansMockWrapper struct {
 	Running bool
+	// CreateRejected makes Create return an error model
+	CreateRejected bool
 }
 
 func (m *ScansMockWrapper) GetWorkflowByID(_ string) ([]*wrappers.ScanTaskResponseModel, *wrappers.ErrorModel, error) {
@@ -18,6 +20,9 @@ func (m *ScansMockWrapper) GetWorkflowByID(_ string) ([]*wrappers.ScanTaskRespon
 
 func (m *ScansMockWrapper) Create(_ *wrappers.Scan) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
 	fmt.Println("Called Create in ScansMockWrapper")
+	if m.CreateRejected {
+		return nil, &wrappers.ErrorModel{Code: 400, Message: "MOCK-REJECTED"}, nil
+	}
 	return &wrappers.ScanResponseModel{
 		ID:     uuid.New().String(),
 		Status: "MOCK",
@@ -43,13 +48,20 @@ func (m *ScansMockWrapper) Get(_ map[string]string) (
 func (m *ScansMockWrapper) GetByID(scanID string) (*wrappers.ScanResponseModel, *wrappers.ErrorModel, error) {
 	fmt.Println("Called GetByID in ScansMockWrapper")
 	var status wrappers.ScanStatus = "Completed"
+	engineStatus := "Completed"
 	if m.Running {
 		status = "Running"
+		engineStatus = "Running"
 	}
 	m.Running = !m.Running
 	return &wrappers.ScanResponseModel{
-		ID:     scanID,
-		Status: status,
+		ID:        scanID,
+		ProjectID: "MOCK",
+		Status:    status,
+		StatusDetails: []wrappers.StatusInfo{
+			{Name: "sast", Status: engineStatus},
+			{Name: "kics", Status: "Completed"},
+		},
 	}, nil, nil
 }
 
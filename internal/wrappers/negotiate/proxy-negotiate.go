@@ -0,0 +1,244 @@
+package negotiate
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/checkmarx/ast-cli/internal/wrappers/ntlm"
+	"github.com/jcmturner/gokrb5/v8/client"
+	"github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+)
+
+const (
+	negotiateScheme     = "negotiate"
+	ntlmScheme          = "ntlm"
+	proxyAuthenticate   = "Proxy-Authenticate"
+	proxyAuthorization  = "Proxy-Authorization"
+	servicePrefix       = "HTTP/"
+	defaultKrb5ConfPath = "/etc/krb5.conf"
+	krb5ConfigEnv       = "KRB5_CONFIG"
+	krb5CCacheEnv       = "KRB5CCNAME"
+	ccacheFilePrefix    = "FILE:"
+	defaultCCacheFormat = "/tmp/krb5cc_%d"
+)
+
+// TokenSource returns the base64 SPNEGO token authenticating to the service principal name
+type TokenSource func(spn string) (string, error)
+
+// KerberosSettings locate the Kerberos configuration and credentials. A keytab is used when KeytabPath is set,
+// the credentials cache otherwise. Empty paths default to KRB5_CONFIG and KRB5CCNAME like the MIT tools.
+type KerberosSettings struct {
+	Krb5ConfPath string
+	KeytabPath   string
+	Principal    string
+	CCachePath   string
+}
+
+// NewKerberosTokenSource returns a TokenSource that logs in on the first token request. A failed login is attempted
+// again on the next request.
+func NewKerberosTokenSource(settings KerberosSettings) TokenSource {
+	var mutex sync.Mutex
+	var loggedIn *client.Client
+	login := func() (*client.Client, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if loggedIn == nil {
+			krbClient, err := newKerberosClient(settings)
+			if err != nil {
+				return nil, err
+			}
+			loggedIn = krbClient
+		}
+		return loggedIn, nil
+	}
+	return func(spn string) (string, error) {
+		krbClient, err := login()
+		if err != nil {
+			return "", err
+		}
+		negotiator := spnego.SPNEGOClient(krbClient, spn)
+		if err = negotiator.AcquireCred(); err != nil {
+			return "", fmt.Errorf("could not acquire the Kerberos credentials: %v", err)
+		}
+		contextToken, err := negotiator.InitSecContext()
+		if err != nil {
+			return "", fmt.Errorf("could not get a Kerberos ticket for %s: %v", spn, err)
+		}
+		token, err := contextToken.Marshal()
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(token), nil
+	}
+}
+
+func newKerberosClient(settings KerberosSettings) (*client.Client, error) {
+	confPath := settings.Krb5ConfPath
+	if confPath == "" {
+		confPath = os.Getenv(krb5ConfigEnv)
+	}
+	if confPath == "" {
+		confPath = defaultKrb5ConfPath
+	}
+	krb5Conf, err := config.Load(confPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the Kerberos configuration %s: %v", confPath, err)
+	}
+
+	if settings.KeytabPath != "" {
+		kt, err := keytab.Load(settings.KeytabPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load the keytab %s: %v", settings.KeytabPath, err)
+		}
+		username, realm := settings.Principal, krb5Conf.LibDefaults.DefaultRealm
+		if separator := strings.LastIndex(username, "@"); separator >= 0 {
+			username, realm = username[:separator], username[separator+1:]
+		}
+		if username == "" {
+			return nil, errors.New("a Kerberos principal is required with a keytab")
+		}
+		krbClient := client.NewWithKeytab(username, realm, kt, krb5Conf, client.DisablePAFXFAST(true))
+		if err = krbClient.Login(); err != nil {
+			return nil, fmt.Errorf("could not log in to Kerberos as %s: %v", settings.Principal, err)
+		}
+		return krbClient, nil
+	}
+
+	ccachePath := settings.CCachePath
+	if ccachePath == "" {
+		ccachePath = strings.TrimPrefix(os.Getenv(krb5CCacheEnv), ccacheFilePrefix)
+	}
+	if ccachePath == "" {
+		ccachePath = fmt.Sprintf(defaultCCacheFormat, os.Getuid())
+	}
+	ccache, err := credentials.LoadCCache(ccachePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the Kerberos credentials cache %s: %v", ccachePath, err)
+	}
+	return client.NewFromCCache(ccache, krb5Conf, client.DisablePAFXFAST(true))
+}
+
+// NewNegotiateProxyDialContext provides a DialContext function that authenticates to the proxy with Negotiate. When
+// the proxy also offers NTLM and Kerberos fails, the connection is retried with ntlmDialContext, if any.
+func NewNegotiateProxyDialContext(dialer *net.Dialer, proxyURL *url.URL, tokens TokenSource,
+	ntlmDialContext ntlm.DialContext, tlsConfig *tls.Config) ntlm.DialContext {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialProxy := func() (net.Conn, error) {
+			if proxyURL.Scheme == "https" {
+				return tls.DialWithDialer(dialer, "tcp", proxyURL.Host, tlsConfig)
+			}
+			return dialer.DialContext(ctx, network, proxyURL.Host)
+		}
+		conn, offers, err := dialAndNegotiate(addr, servicePrefix+proxyURL.Hostname(), tokens, dialProxy)
+		if err != nil && offers[ntlmScheme] && ntlmDialContext != nil {
+			log.Printf("Negotiate proxy authentication failed, falling back to NTLM: %s", err)
+			return ntlmDialContext(ctx, network, addr)
+		}
+		return conn, err
+	}
+}
+
+// dialAndNegotiate sends an anonymous CONNECT to learn the offered schemes and answers a Negotiate challenge with
+// a Kerberos token. It returns the offered schemes so that the caller can fall back to another one.
+func dialAndNegotiate(addr, spn string, tokens TokenSource, baseDial func() (net.Conn, error)) (
+	net.Conn, map[string]bool, error) {
+	offers := map[string]bool{}
+	conn, err := baseDial()
+	if err != nil {
+		log.Printf("Could not call dial context with proxy: %s", err)
+		return nil, offers, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := connect(conn, br, addr, "")
+	if err != nil {
+		_ = conn.Close()
+		return nil, offers, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return conn, offers, nil
+	}
+	if resp.StatusCode != http.StatusProxyAuthRequired {
+		_ = conn.Close()
+		return nil, offers, errors.New(http.StatusText(resp.StatusCode))
+	}
+	for _, challenge := range resp.Header.Values(proxyAuthenticate) {
+		offers[strings.ToLower(strings.Fields(challenge + " ")[0])] = true
+	}
+	if !offers[negotiateScheme] {
+		_ = conn.Close()
+		return nil, offers, fmt.Errorf("the proxy does not offer Negotiate, got: '%s'", resp.Header.Get(proxyAuthenticate))
+	}
+
+	token, err := tokens(spn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, offers, err
+	}
+	if resp.Close {
+		_ = conn.Close()
+		if conn, err = baseDial(); err != nil {
+			return nil, offers, err
+		}
+		br = bufio.NewReader(conn)
+	}
+	resp, err = connect(conn, br, addr, "Negotiate "+token)
+	if err != nil {
+		_ = conn.Close()
+		return nil, offers, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("Expected %d as return status, got: %d", http.StatusOK, resp.StatusCode)
+		_ = conn.Close()
+		return nil, offers, errors.New(http.StatusText(resp.StatusCode))
+	}
+	// Successfully authorized with Negotiate
+	return conn, offers, nil
+}
+
+// connect writes a CONNECT request for addr and reads the proxy response
+func connect(conn net.Conn, br *bufio.Reader, addr, authorization string) (*http.Response, error) {
+	header := make(http.Header)
+	header.Set("Proxy-Connection", "Keep-Alive")
+	if authorization != "" {
+		header.Set(proxyAuthorization, authorization)
+	}
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: header,
+	}
+	if err := request.Write(conn); err != nil {
+		log.Printf("Could not write CONNECT to proxy: %s", err)
+		return nil, err
+	}
+	resp, err := http.ReadResponse(br, request)
+	if err != nil {
+		log.Printf("Could not read response from proxy: %s", err)
+		return nil, err
+	}
+	// The body of an established tunnel is the tunneled stream
+	if resp.StatusCode != http.StatusOK {
+		_, _ = ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	return resp, nil
+}
@@ -0,0 +1,160 @@
+package negotiate
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const (
+	testTarget = "ast.example.com:443"
+	testToken  = "dGlja2V0"
+)
+
+// standInProxy answers CONNECT requests with the challenges until the expected authorization is sent, then echoes
+func standInProxy(t *testing.T, challenges []string, authorization string) *url.URL {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go serveStandInProxy(conn, challenges, authorization)
+		}
+	}()
+	return &url.URL{Scheme: "http", Host: listener.Addr().String()}
+}
+
+func serveStandInProxy(conn net.Conn, challenges []string, authorization string) {
+	defer func() { _ = conn.Close() }()
+	br := bufio.NewReader(conn)
+	for {
+		request, err := http.ReadRequest(br)
+		if err != nil || request.Method != http.MethodConnect || request.Host != testTarget {
+			return
+		}
+		if request.Header.Get(proxyAuthorization) == authorization {
+			_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+			_, _ = io.Copy(conn, br)
+			return
+		}
+		response := "HTTP/1.1 407 Proxy Authentication Required\r\nContent-Length: 0\r\n"
+		for _, challenge := range challenges {
+			response += proxyAuthenticate + ": " + challenge + "\r\n"
+		}
+		_, _ = io.WriteString(conn, response+"\r\n")
+	}
+}
+
+func assertEcho(t *testing.T, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_, err := io.WriteString(conn, "ping\n")
+	assert.NilError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	assert.NilError(t, err)
+	assert.Equal(t, line, "ping\n")
+}
+
+func failingNTLM(called *bool) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		*called = true
+		return nil, errors.New("NTLM fallback")
+	}
+}
+
+func TestNegotiateProxyDialContext(t *testing.T) {
+	proxyURL := standInProxy(t, []string{"Negotiate", "NTLM"}, "Negotiate "+testToken)
+	var spn string
+	tokens := func(requested string) (string, error) {
+		spn = requested
+		return testToken, nil
+	}
+	ntlmCalled := false
+	dial := NewNegotiateProxyDialContext(nil, proxyURL, tokens, failingNTLM(&ntlmCalled), nil)
+
+	conn, err := dial(context.Background(), "tcp", testTarget)
+	assert.NilError(t, err)
+	assertEcho(t, conn)
+	assert.Equal(t, spn, "HTTP/127.0.0.1")
+	assert.Assert(t, !ntlmCalled)
+}
+
+func TestNegotiateProxyDialContextWithoutChallenge(t *testing.T) {
+	proxyURL := standInProxy(t, nil, "")
+	tokens := func(string) (string, error) {
+		return "", errors.New("no ticket")
+	}
+	dial := NewNegotiateProxyDialContext(nil, proxyURL, tokens, nil, nil)
+
+	conn, err := dial(context.Background(), "tcp", testTarget)
+	assert.NilError(t, err)
+	assertEcho(t, conn)
+}
+
+func TestNegotiateProxyDialContextFallsBackToNTLM(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens TokenSource
+	}{
+		{"No Kerberos ticket", func(string) (string, error) { return "", errors.New("no ticket") }},
+		{"Rejected Kerberos ticket", func(string) (string, error) { return "cmVqZWN0ZWQ=", nil }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				proxyURL := standInProxy(t, []string{"Negotiate", "NTLM"}, "Negotiate "+testToken)
+				ntlmCalled := false
+				dial := NewNegotiateProxyDialContext(nil, proxyURL, tt.tokens, failingNTLM(&ntlmCalled), nil)
+
+				_, err := dial(context.Background(), "tcp", testTarget)
+				assert.ErrorContains(t, err, "NTLM fallback")
+				assert.Assert(t, ntlmCalled)
+			},
+		)
+	}
+}
+
+func TestNegotiateProxyDialContextNotOffered(t *testing.T) {
+	proxyURL := standInProxy(t, []string{`Basic realm="proxy"`}, "Negotiate "+testToken)
+	ntlmCalled := false
+	tokens := func(string) (string, error) {
+		return testToken, nil
+	}
+	dial := NewNegotiateProxyDialContext(nil, proxyURL, tokens, failingNTLM(&ntlmCalled), nil)
+
+	_, err := dial(context.Background(), "tcp", testTarget)
+	assert.ErrorContains(t, err, "does not offer Negotiate")
+	assert.Assert(t, !ntlmCalled)
+}
+
+func TestKerberosTokenSourceMissingConfiguration(t *testing.T) {
+	tokens := NewKerberosTokenSource(KerberosSettings{Krb5ConfPath: "/nonexistent/krb5.conf"})
+
+	_, err := tokens("HTTP/proxy.example.com")
+	assert.ErrorContains(t, err, "could not load the Kerberos configuration /nonexistent/krb5.conf")
+}
+
+func TestKerberosTokenSourceRetriesFailedLogin(t *testing.T) {
+	dir := t.TempDir()
+	krb5ConfPath := filepath.Join(dir, "krb5.conf")
+	tokens := NewKerberosTokenSource(KerberosSettings{Krb5ConfPath: krb5ConfPath, CCachePath: filepath.Join(dir, "ccache")})
+
+	_, err := tokens("HTTP/proxy.example.com")
+	assert.ErrorContains(t, err, "could not load the Kerberos configuration")
+	assert.NilError(t, ioutil.WriteFile(krb5ConfPath, []byte("[libdefaults]\n  default_realm = EXAMPLE.COM\n"), 0600))
+	_, err = tokens("HTTP/proxy.example.com")
+	assert.ErrorContains(t, err, "could not load the Kerberos credentials cache", "The login should be attempted again")
+}
@@ -0,0 +1,363 @@
+package wrappers
+
+import (
+	"strconv"
+	"sync"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultPageSize = 100
+	resultsPageSize = 10000
+)
+
+var errPageErrorModel = errors.New("the server returned an error model")
+
+// PageParams returns a copy of params requesting the page of pageSize items starting at offset
+func PageParams(params map[string]string, offset, pageSize int) map[string]string {
+	pageParams := make(map[string]string, len(params)+2)
+	for key, value := range params {
+		pageParams[key] = value
+	}
+	pageParams[commonParams.OffsetQueryParam] = strconv.Itoa(offset)
+	pageParams[commonParams.LimitQueryParam] = strconv.Itoa(pageSize)
+	return pageParams
+}
+
+// pager keeps track of the position of an iterator in a paginated listing
+type pager struct {
+	params   map[string]string
+	pageSize int
+	offset   int
+	done     bool
+	err      error
+}
+
+func newPager(params map[string]string, pageSize int) pager {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	return pager{params: params, pageSize: pageSize}
+}
+
+func (p *pager) nextParams() map[string]string {
+	return PageParams(p.params, p.offset, p.pageSize)
+}
+
+// advance moves past a page of count items out of total. The listing ends with the first short page.
+func (p *pager) advance(count int, total uint) {
+	p.offset += count
+	p.done = count < p.pageSize || (total > 0 && p.offset >= int(total))
+}
+
+func (p *pager) fail(errorModel *ErrorModel, err error) bool {
+	if err == nil && errorModel != nil {
+		err = errors.Errorf("CODE: %d, %s", errorModel.Code, errorModel.Message)
+	}
+	p.err = err
+	p.done = p.done || err != nil
+	return err != nil
+}
+
+// ScansIterator streams the scans matching the params, fetching a page at a time
+//
+//	it := NewScansIterator(scansWrapper, params, DefaultPageSize)
+//	for it.Next() {
+//		scan := it.Scan()
+//	}
+//	err := it.Err()
+type ScansIterator struct {
+	pager
+	wrapper ScansWrapper
+	page    []ScanResponseModel
+	index   int
+}
+
+func NewScansIterator(wrapper ScansWrapper, params map[string]string, pageSize int) *ScansIterator {
+	return &ScansIterator{pager: newPager(params, pageSize), wrapper: wrapper, index: -1}
+}
+
+func (it *ScansIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		model, errorModel, err := it.wrapper.Get(it.nextParams())
+		if it.fail(errorModel, err) {
+			return false
+		}
+		it.page, it.index = nil, 0
+		if model == nil {
+			it.done = true
+			return false
+		}
+		it.page = model.Scans
+		it.advance(len(model.Scans), model.FilteredTotalCount)
+	}
+	return true
+}
+
+func (it *ScansIterator) Scan() *ScanResponseModel {
+	return &it.page[it.index]
+}
+
+func (it *ScansIterator) Err() error {
+	return it.err
+}
+
+// ProjectsIterator streams the projects matching the params, fetching a page at a time
+type ProjectsIterator struct {
+	pager
+	wrapper ProjectsWrapper
+	page    []ProjectResponseModel
+	index   int
+}
+
+func NewProjectsIterator(wrapper ProjectsWrapper, params map[string]string, pageSize int) *ProjectsIterator {
+	return &ProjectsIterator{pager: newPager(params, pageSize), wrapper: wrapper, index: -1}
+}
+
+func (it *ProjectsIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		model, errorModel, err := it.wrapper.Get(it.nextParams())
+		if it.fail(errorModel, err) {
+			return false
+		}
+		it.page, it.index = nil, 0
+		if model == nil {
+			it.done = true
+			return false
+		}
+		it.page = model.Projects
+		it.advance(len(model.Projects), model.FilteredTotalCount)
+	}
+	return true
+}
+
+func (it *ProjectsIterator) Project() *ProjectResponseModel {
+	return &it.page[it.index]
+}
+
+func (it *ProjectsIterator) Err() error {
+	return it.err
+}
+
+// pageFetcher fetches the page at the params and returns its item count and the total item count, 0 when unknown
+type pageFetcher func(page int, params map[string]string) (count int, total uint, err error)
+
+// fetchAllPages fetches the first page to learn the total and then the remaining pages concurrently. When the
+// total is unknown the pages are fetched one by one until a short one. It returns the number of pages fetched.
+func fetchAllPages(params map[string]string, pageSize, concurrency int, fetch pageFetcher) (int, error) {
+	count, total, err := fetch(0, PageParams(params, 0, pageSize))
+	if err != nil {
+		return 0, err
+	}
+	pages := 1
+	if count < pageSize {
+		return pages, nil
+	}
+	if total == 0 {
+		for count == pageSize {
+			count, _, err = fetch(pages, PageParams(params, pages*pageSize, pageSize))
+			if err != nil {
+				return 0, err
+			}
+			pages++
+		}
+		return pages, nil
+	}
+
+	pages = (int(total) + pageSize - 1) / pageSize
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	errs := make(chan error, pages)
+	workers := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for page := 1; page < pages; page++ {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(page int) {
+			defer wg.Done()
+			defer func() { <-workers }()
+			if _, _, fetchErr := fetch(page, PageParams(params, page*pageSize, pageSize)); fetchErr != nil {
+				errs <- fetchErr
+			}
+		}(page)
+	}
+	wg.Wait()
+	close(errs)
+	return pages, <-errs
+}
+
+// AllPagesScansWrapper is a ScansWrapper whose Get returns the scans of every page
+type AllPagesScansWrapper struct {
+	ScansWrapper
+	pageSize    int
+	concurrency int
+}
+
+func NewAllPagesScansWrapper(wrapper ScansWrapper, pageSize, concurrency int) ScansWrapper {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	return &AllPagesScansWrapper{ScansWrapper: wrapper, pageSize: pageSize, concurrency: concurrency}
+}
+
+func (w *AllPagesScansWrapper) Get(params map[string]string) (*ScansCollectionResponseModel, *ErrorModel, error) {
+	var lock sync.Mutex
+	models := make(map[int]*ScansCollectionResponseModel)
+	var firstErrorModel *ErrorModel
+	pages, err := fetchAllPages(
+		params, w.pageSize, w.concurrency, func(page int, pageParams map[string]string) (int, uint, error) {
+			model, errorModel, err := w.ScansWrapper.Get(pageParams)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				return 0, 0, err
+			}
+			if errorModel != nil {
+				if firstErrorModel == nil {
+					firstErrorModel = errorModel
+				}
+				return 0, 0, errPageErrorModel
+			}
+			if model == nil {
+				return 0, 0, nil
+			}
+			models[page] = model
+			return len(model.Scans), model.FilteredTotalCount, nil
+		},
+	)
+	if firstErrorModel != nil {
+		return nil, firstErrorModel, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	all := &ScansCollectionResponseModel{}
+	for page := 0; page < pages; page++ {
+		if model, found := models[page]; found {
+			all.TotalCount = model.TotalCount
+			all.FilteredTotalCount = model.FilteredTotalCount
+			all.Scans = append(all.Scans, model.Scans...)
+		}
+	}
+	return all, nil, nil
+}
+
+// AllPagesProjectsWrapper is a ProjectsWrapper whose Get returns the projects of every page
+type AllPagesProjectsWrapper struct {
+	ProjectsWrapper
+	pageSize    int
+	concurrency int
+}
+
+func NewAllPagesProjectsWrapper(wrapper ProjectsWrapper, pageSize, concurrency int) ProjectsWrapper {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	return &AllPagesProjectsWrapper{ProjectsWrapper: wrapper, pageSize: pageSize, concurrency: concurrency}
+}
+
+func (w *AllPagesProjectsWrapper) Get(params map[string]string) (*ProjectsCollectionResponseModel, *ErrorModel, error) {
+	var lock sync.Mutex
+	models := make(map[int]*ProjectsCollectionResponseModel)
+	var firstErrorModel *ErrorModel
+	pages, err := fetchAllPages(
+		params, w.pageSize, w.concurrency, func(page int, pageParams map[string]string) (int, uint, error) {
+			model, errorModel, err := w.ProjectsWrapper.Get(pageParams)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				return 0, 0, err
+			}
+			if errorModel != nil {
+				if firstErrorModel == nil {
+					firstErrorModel = errorModel
+				}
+				return 0, 0, errPageErrorModel
+			}
+			if model == nil {
+				return 0, 0, nil
+			}
+			models[page] = model
+			return len(model.Projects), model.FilteredTotalCount, nil
+		},
+	)
+	if firstErrorModel != nil {
+		return nil, firstErrorModel, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	all := &ProjectsCollectionResponseModel{}
+	for page := 0; page < pages; page++ {
+		if model, found := models[page]; found {
+			all.TotalCount = model.TotalCount
+			all.FilteredTotalCount = model.FilteredTotalCount
+			all.Projects = append(all.Projects, model.Projects...)
+		}
+	}
+	return all, nil, nil
+}
+
+// AllPagesResultsWrapper is a ResultsWrapper whose GetAllResultsByScanID returns the results of every page. The
+// page size is the maximum number of results the server returns at once.
+type AllPagesResultsWrapper struct {
+	ResultsWrapper
+	concurrency int
+}
+
+func NewAllPagesResultsWrapper(wrapper ResultsWrapper, concurrency int) ResultsWrapper {
+	return &AllPagesResultsWrapper{ResultsWrapper: wrapper, concurrency: concurrency}
+}
+
+func (w *AllPagesResultsWrapper) GetAllResultsByScanID(params map[string]string) (*ScanResultsCollection, *WebError, error) {
+	var lock sync.Mutex
+	models := make(map[int]*ScanResultsCollection)
+	var firstWebError *WebError
+	pages, err := fetchAllPages(
+		params, resultsPageSize, w.concurrency, func(page int, pageParams map[string]string) (int, uint, error) {
+			model, webError, err := w.ResultsWrapper.GetAllResultsByScanID(pageParams)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				return 0, 0, err
+			}
+			if webError != nil {
+				if firstWebError == nil {
+					firstWebError = webError
+				}
+				return 0, 0, errPageErrorModel
+			}
+			if model == nil {
+				return 0, 0, nil
+			}
+			models[page] = model
+			return len(model.Results), model.TotalCount, nil
+		},
+	)
+	if firstWebError != nil {
+		return nil, firstWebError, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	all := &ScanResultsCollection{}
+	for page := 0; page < pages; page++ {
+		if model, found := models[page]; found {
+			all.TotalCount = model.TotalCount
+			all.ScanID = model.ScanID
+			all.Results = append(all.Results, model.Results...)
+		}
+	}
+	return all, nil, nil
+}
@@ -0,0 +1,221 @@
+//go:build !integration
+
+package wrappers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+// pagedFetcher serves a listing of items, recording the offsets it was asked for
+type pagedFetcher struct {
+	items     int
+	withTotal bool
+	failPage  int
+	lock      sync.Mutex
+	offsets   []int
+}
+
+func (f *pagedFetcher) fetch(page int, params map[string]string) (int, uint, error) {
+	offset, _ := strconv.Atoi(params[commonParams.OffsetQueryParam])
+	limit, _ := strconv.Atoi(params[commonParams.LimitQueryParam])
+	f.lock.Lock()
+	f.offsets = append(f.offsets, offset)
+	f.lock.Unlock()
+	if page == f.failPage {
+		return 0, 0, errors.Errorf("page %d failed", page)
+	}
+	count := f.items - offset
+	if count > limit {
+		count = limit
+	}
+	if count < 0 {
+		count = 0
+	}
+	total := uint(0)
+	if f.withTotal {
+		total = uint(f.items)
+	}
+	return count, total, nil
+}
+
+func TestFetchAllPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		withTotal bool
+		failPage  int
+		pages     int
+		offsets   []int
+		err       string
+	}{
+		{name: "known total", items: 25, withTotal: true, failPage: -1, pages: 3, offsets: []int{0, 10, 20}},
+		{name: "known total on a page boundary", items: 20, withTotal: true, failPage: -1, pages: 2, offsets: []int{0, 10}},
+		{name: "short first page", items: 5, withTotal: true, failPage: -1, pages: 1, offsets: []int{0}},
+		{name: "empty listing", items: 0, failPage: -1, pages: 1, offsets: []int{0}},
+		{name: "unknown total", items: 25, failPage: -1, pages: 3, offsets: []int{0, 10, 20}},
+		{name: "unknown total on a page boundary", items: 20, failPage: -1, pages: 3, offsets: []int{0, 10, 20}},
+		{name: "error on the first page", items: 25, withTotal: true, failPage: 0, offsets: []int{0}, err: "page 0 failed"},
+		{name: "error on a sequential page", items: 25, failPage: 1, offsets: []int{0, 10}, err: "page 1 failed"},
+		{name: "error on a concurrent page", items: 55, withTotal: true, failPage: 3, pages: 6, offsets: []int{0, 10, 20, 30, 40, 50}, err: "page 3 failed"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fetcher := &pagedFetcher{items: tt.items, withTotal: tt.withTotal, failPage: tt.failPage}
+				pages, err := fetchAllPages(map[string]string{"name": "x"}, 10, 3, fetcher.fetch)
+				if tt.err != "" {
+					assert.Error(t, err, tt.err)
+				} else {
+					assert.NilError(t, err)
+				}
+				if tt.pages > 0 {
+					assert.Equal(t, pages, tt.pages)
+				}
+				sort.Ints(fetcher.offsets)
+				assert.DeepEqual(t, fetcher.offsets, tt.offsets)
+			},
+		)
+	}
+}
+
+// pagedListing serves item IDs by offset and limit, with an error model on the page at errorOffset
+type pagedListing struct {
+	items       int
+	withTotal   bool
+	errorOffset int
+	lock        sync.Mutex
+	requests    int
+}
+
+func (w *pagedListing) page(params map[string]string) ([]string, uint, *ErrorModel) {
+	w.lock.Lock()
+	w.requests++
+	w.lock.Unlock()
+	offset, _ := strconv.Atoi(params[commonParams.OffsetQueryParam])
+	limit, _ := strconv.Atoi(params[commonParams.LimitQueryParam])
+	if offset == w.errorOffset {
+		return nil, 0, &ErrorModel{Code: 500, Message: "MOCK"}
+	}
+	var ids []string
+	for i := offset; i < offset+limit && i < w.items; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	total := uint(0)
+	if w.withTotal {
+		total = uint(w.items)
+	}
+	return ids, total, nil
+}
+
+type pagedScansWrapper struct {
+	ScansWrapper
+	*pagedListing
+}
+
+func (w pagedScansWrapper) Get(params map[string]string) (*ScansCollectionResponseModel, *ErrorModel, error) {
+	ids, total, errorModel := w.page(params)
+	if errorModel != nil {
+		return nil, errorModel, nil
+	}
+	model := &ScansCollectionResponseModel{FilteredTotalCount: total}
+	for _, id := range ids {
+		model.Scans = append(model.Scans, ScanResponseModel{ID: id})
+	}
+	return model, nil, nil
+}
+
+type pagedProjectsWrapper struct {
+	ProjectsWrapper
+	*pagedListing
+}
+
+func (w pagedProjectsWrapper) Get(params map[string]string) (*ProjectsCollectionResponseModel, *ErrorModel, error) {
+	ids, total, errorModel := w.page(params)
+	if errorModel != nil {
+		return nil, errorModel, nil
+	}
+	model := &ProjectsCollectionResponseModel{FilteredTotalCount: total}
+	for _, id := range ids {
+		model.Projects = append(model.Projects, ProjectResponseModel{ID: id})
+	}
+	return model, nil, nil
+}
+
+func TestIterators(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       int
+		withTotal   bool
+		errorOffset int
+		ids         int
+		requests    int
+		err         string
+	}{
+		{name: "known total", items: 10, withTotal: true, errorOffset: -1, ids: 10, requests: 3},
+		{name: "known total on a page boundary", items: 8, withTotal: true, errorOffset: -1, ids: 8, requests: 2},
+		{name: "unknown total on a page boundary", items: 8, errorOffset: -1, ids: 8, requests: 3},
+		{name: "empty listing", items: 0, errorOffset: -1, ids: 0, requests: 1},
+		{name: "error model", items: 10, withTotal: true, errorOffset: 4, ids: 4, requests: 2, err: "CODE: 500, MOCK"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				scans := pagedScansWrapper{pagedListing: &pagedListing{items: tt.items, withTotal: tt.withTotal, errorOffset: tt.errorOffset}}
+				scansIt := NewScansIterator(scans, map[string]string{}, 4)
+				var scanIDs []string
+				for scansIt.Next() {
+					scanIDs = append(scanIDs, scansIt.Scan().ID)
+				}
+				assert.Assert(t, !scansIt.Next(), "The iterator should stay done")
+
+				projects := pagedProjectsWrapper{pagedListing: &pagedListing{items: tt.items, withTotal: tt.withTotal, errorOffset: tt.errorOffset}}
+				projectsIt := NewProjectsIterator(projects, map[string]string{}, 4)
+				var projectIDs []string
+				for projectsIt.Next() {
+					projectIDs = append(projectIDs, projectsIt.Project().ID)
+				}
+
+				for _, it := range []struct {
+					ids      []string
+					requests int
+					err      error
+				}{{scanIDs, scans.requests, scansIt.Err()}, {projectIDs, projects.requests, projectsIt.Err()}} {
+					assert.Equal(t, len(it.ids), tt.ids)
+					for i, id := range it.ids {
+						assert.Equal(t, id, fmt.Sprint(i))
+					}
+					assert.Equal(t, it.requests, tt.requests)
+					if tt.err != "" {
+						assert.Error(t, it.err, tt.err)
+					} else {
+						assert.NilError(t, it.err)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestAllPagesWrappersReturnTheErrorModel(t *testing.T) {
+	scans := pagedScansWrapper{pagedListing: &pagedListing{items: 10, withTotal: true, errorOffset: 8}}
+	_, errorModel, err := NewAllPagesScansWrapper(scans, 4, 2).Get(map[string]string{})
+	assert.NilError(t, err)
+	assert.Equal(t, errorModel.Message, "MOCK")
+
+	projects := pagedProjectsWrapper{pagedListing: &pagedListing{items: 10, withTotal: true, errorOffset: -1}}
+	model, errorModel, err := NewAllPagesProjectsWrapper(projects, 4, 2).Get(map[string]string{})
+	assert.NilError(t, err)
+	assert.Assert(t, errorModel == nil)
+	assert.Equal(t, len(model.Projects), 10)
+	assert.Equal(t, model.Projects[9].ID, "9")
+}
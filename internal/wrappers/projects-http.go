@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -11,6 +12,12 @@ import (
 	commonParams "github.com/checkmarx/ast-cli/internal/params"
 )
 
+const (
+	projectConfigurationPath      = "api/configuration/project"
+	configurationKeysQueryParam   = "config-keys"
+	failedToParseProjectConfigure = "Failed to parse project configuration response"
+)
+
 type ProjectsHTTPWrapper struct {
 	path string
 }
@@ -37,6 +44,20 @@ func (p *ProjectsHTTPWrapper) Create(model *Project) (
 	return handleProjectResponseWithBody(resp, err, http.StatusCreated)
 }
 
+func (p *ProjectsHTTPWrapper) Update(projectID string, model *Project) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	jsonBytes, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := SendHTTPRequest(http.MethodPut, p.path+"/"+projectID, bytes.NewBuffer(jsonBytes), true, clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return handleProjectResponseWithNoBody(resp, err, http.StatusNoContent)
+}
+
 func (p *ProjectsHTTPWrapper) UpdateConfiguration(projectID string, configuration []ProjectConfiguration) (*ErrorModel, error) {
 	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
 	jsonBytes, err := json.Marshal(configuration)
@@ -48,7 +69,58 @@ func (p *ProjectsHTTPWrapper) UpdateConfiguration(projectID string, configuratio
 		commonParams.ProjectIDFlag: projectID,
 	}
 
-	resp, err := SendHTTPRequestWithQueryParams(http.MethodPatch, "api/configuration/project", params, bytes.NewBuffer(jsonBytes), clientTimeout)
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodPatch, projectConfigurationPath, params, bytes.NewBuffer(jsonBytes), clientTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return handleProjectResponseWithNoBody(resp, err, http.StatusNoContent)
+}
+
+func (p *ProjectsHTTPWrapper) GetConfiguration(projectID string) ([]ProjectConfiguration, *ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag: projectID,
+	}
+
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodGet, projectConfigurationPath, params, nil, clientTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	decoder := json.NewDecoder(resp.Body)
+
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		errorModel := ErrorModel{}
+		err = decoder.Decode(&errorModel)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseErr)
+		}
+		return nil, &errorModel, nil
+	case http.StatusNotFound:
+		return nil, nil, errors.Errorf("project not found")
+	case http.StatusOK:
+		var configuration []ProjectConfiguration
+		err = decoder.Decode(&configuration)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, failedToParseProjectConfigure)
+		}
+		return configuration, nil, nil
+
+	default:
+		return nil, nil, errors.Errorf("response status code %d", resp.StatusCode)
+	}
+}
+
+func (p *ProjectsHTTPWrapper) DeleteConfiguration(projectID string, keys []string) (*ErrorModel, error) {
+	clientTimeout := viper.GetUint(commonParams.ClientTimeoutKey)
+	params := map[string]string{
+		commonParams.ProjectIDFlag:  projectID,
+		configurationKeysQueryParam: strings.Join(keys, ","),
+	}
+
+	resp, err := SendHTTPRequestWithQueryParams(http.MethodDelete, projectConfigurationPath, params, nil, clientTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,241 @@
+package wrappers
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	proxyRuleSeparator = "="
+	proxyRuleDirect    = "direct"
+	noProxyAll         = "*"
+	httpsScheme        = "https"
+	basicProxyType     = "basic"
+)
+
+// ProxyRoute is the proxy a request goes through and how the client authenticates to it
+type ProxyRoute struct {
+	URL *url.URL
+	// Type is basic, ntlm or negotiate
+	Type string
+}
+
+// proxyRule routes the hosts matching pattern through proxy, or directly when proxy is nil
+type proxyRule struct {
+	pattern string
+	proxy   *url.URL
+}
+
+// proxyRouter picks the proxy of a request. The first matching per-host rule wins, then the hosts matching
+// NO_PROXY are reached directly, then HTTPS requests go through HTTPS_PROXY and everything else through --proxy.
+type proxyRouter struct {
+	proxy      *url.URL
+	httpsProxy *url.URL
+	noProxy    []string
+	rules      []proxyRule
+}
+
+func newProxyRouter() (*proxyRouter, error) {
+	router := &proxyRouter{}
+	var err error
+	if router.proxy, err = parseProxyURL(getProxySetting(commonParams.ProxyKey, commonParams.ProxyEnv)); err != nil {
+		return nil, err
+	}
+	router.httpsProxy, err = parseProxyURL(getProxySetting(commonParams.HTTPSProxyKey, commonParams.HTTPSProxyEnv))
+	if err != nil {
+		return nil, err
+	}
+	noProxy := getProxySetting(commonParams.NoProxyKey, commonParams.NoProxyEnv)
+	for _, pattern := range splitProxyList([]string{noProxy}) {
+		router.noProxy = append(router.noProxy, strings.ToLower(pattern))
+	}
+	for _, rule := range splitProxyList(viper.GetStringSlice(commonParams.ProxyRulesKey)) {
+		separator := strings.Index(rule, proxyRuleSeparator)
+		if separator < 1 {
+			return nil, errors.Errorf("Invalid proxy rule %s, expected <host>=<proxy URL or %s>", rule, proxyRuleDirect)
+		}
+		parsed := proxyRule{pattern: strings.ToLower(strings.TrimSpace(rule[:separator]))}
+		target := strings.TrimSpace(rule[separator+1:])
+		if !strings.EqualFold(target, proxyRuleDirect) {
+			if parsed.proxy, err = parseProxyURL(target); err != nil {
+				return nil, err
+			}
+			if parsed.proxy == nil {
+				return nil, errors.Errorf("Invalid proxy rule %s, expected <host>=<proxy URL or %s>", rule, proxyRuleDirect)
+			}
+		}
+		router.rules = append(router.rules, parsed)
+	}
+	return router, nil
+}
+
+// ResolveProxy returns the proxy the clients use for rawURL, nil when it is reached directly. Only --proxy
+// authenticates with --proxy-auth-type, HTTPS_PROXY and the per-host rule proxies are basic ones.
+func ResolveProxy(rawURL string) (*ProxyRoute, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	router, err := newProxyRouter()
+	if err != nil {
+		return nil, err
+	}
+	proxy := router.proxyFor(u)
+	if proxy == nil {
+		return nil, nil
+	}
+	route := &ProxyRoute{URL: proxy, Type: basicProxyType}
+	proxyType := viper.GetString(commonParams.ProxyTypeKey)
+	if (proxyType == ntlmProxyToken || proxyType == negotiateProxyToken) &&
+		router.proxy != nil && proxy.Host == router.proxy.Host {
+		route.Type = proxyType
+	}
+	return route, nil
+}
+
+// getProxySetting falls back to the lowercase variant of the environment variable, as curl and Go do
+func getProxySetting(key, env string) string {
+	if value := viper.GetString(key); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToLower(env))
+}
+
+func parseProxyURL(proxyStr string) (*url.URL, error) {
+	proxyStr = strings.TrimSpace(proxyStr)
+	if proxyStr == "" {
+		return nil, nil
+	}
+	if !strings.Contains(proxyStr, "://") {
+		proxyStr = "http://" + proxyStr
+	}
+	u, err := url.Parse(proxyStr)
+	if err != nil || u.Host == "" {
+		return nil, errors.Errorf("Invalid proxy URL %s", proxyStr)
+	}
+	return u, nil
+}
+
+// splitProxyList flattens comma separated entries, as read from the environment, flags or the config file
+func splitProxyList(values []string) []string {
+	var entries []string
+	for _, value := range values {
+		for _, entry := range strings.Split(value, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// proxyFor returns the proxy of the request URL, nil when it is reached directly
+func (r *proxyRouter) proxyFor(u *url.URL) *url.URL {
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == httpsScheme {
+			port = "443"
+		}
+	}
+	if rule, found := r.matchRule(host, port); found {
+		return rule.proxy
+	}
+	if r.matchNoProxy(host, port) {
+		return nil
+	}
+	if u.Scheme == httpsScheme && r.httpsProxy != nil {
+		return r.httpsProxy
+	}
+	return r.proxy
+}
+
+// usesDefaultProxy tells if address is reached through --proxy, whatever the scheme of the request
+func (r *proxyRouter) usesDefaultProxy(address string) bool {
+	if r.proxy == nil {
+		return false
+	}
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = address, ""
+	}
+	if r.isOtherProxy(address) {
+		return false
+	}
+	if rule, found := r.matchRule(host, port); found {
+		return rule.proxy != nil && rule.proxy.Host == r.proxy.Host
+	}
+	return !r.matchNoProxy(host, port)
+}
+
+// isOtherProxy tells if address is one of the proxies other than --proxy, which are always dialed directly
+func (r *proxyRouter) isOtherProxy(address string) bool {
+	if r.httpsProxy != nil && r.httpsProxy.Host != r.proxy.Host && proxyAddress(r.httpsProxy) == address {
+		return true
+	}
+	for _, rule := range r.rules {
+		if rule.proxy != nil && rule.proxy.Host != r.proxy.Host && proxyAddress(rule.proxy) == address {
+			return true
+		}
+	}
+	return false
+}
+
+func proxyAddress(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == httpsScheme {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+func (r *proxyRouter) matchRule(host, port string) (proxyRule, bool) {
+	for _, rule := range r.rules {
+		if matchProxyPattern(rule.pattern, host, port) {
+			return rule, true
+		}
+	}
+	return proxyRule{}, false
+}
+
+func (r *proxyRouter) matchNoProxy(host, port string) bool {
+	for _, pattern := range r.noProxy {
+		if matchProxyPattern(pattern, host, port) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchProxyPattern follows the NO_PROXY conventions: * matches every host, an IP or CIDR matches the addresses
+// it covers, and a domain matches itself and its subdomains, with an optional leading . or *. and :port
+func matchProxyPattern(pattern, host, port string) bool {
+	host = strings.ToLower(host)
+	if pattern == noProxyAll {
+		return true
+	}
+	if _, cidr, err := net.ParseCIDR(pattern); err == nil {
+		ip := net.ParseIP(host)
+		return ip != nil && cidr.Contains(ip)
+	}
+	if patternHost, patternPort, err := net.SplitHostPort(pattern); err == nil {
+		if patternPort != port {
+			return false
+		}
+		pattern = patternHost
+	}
+	if ip := net.ParseIP(pattern); ip != nil {
+		return ip.Equal(net.ParseIP(host))
+	}
+	pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "*"), ".")
+	return host == pattern || strings.HasSuffix(host, "."+pattern)
+}
@@ -0,0 +1,288 @@
+//go:build !integration
+
+package wrappers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	commonParams "github.com/checkmarx/ast-cli/internal/params"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+// useProxySettings configures the proxies for the test only, ignoring the proxy environment variables
+func useProxySettings(t *testing.T, proxy, httpsProxy, noProxy string, rules ...string) *proxyRouter {
+	for _, env := range []string{"http_proxy", "https_proxy", "no_proxy"} {
+		t.Setenv(env, "")
+	}
+	useSettings(
+		t, map[string]interface{}{
+			commonParams.ProxyKey:      proxy,
+			commonParams.HTTPSProxyKey: httpsProxy,
+			commonParams.NoProxyKey:    noProxy,
+			commonParams.ProxyRulesKey: rules,
+		},
+	)
+	router, err := newProxyRouter()
+	assert.NilError(t, err)
+	return router
+}
+
+func TestMatchProxyPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		host    string
+		port    string
+		matches bool
+	}{
+		{"*", "example.com", "443", true},
+		{"example.com", "example.com", "443", true},
+		{"example.com", "api.example.com", "443", true},
+		{"example.com", "EXAMPLE.com", "443", true},
+		{"example.com", "notexample.com", "443", false},
+		{".example.com", "api.example.com", "443", true},
+		{".example.com", "example.com", "443", true},
+		{"*.example.com", "api.example.com", "443", true},
+		{"example.com:8443", "example.com", "8443", true},
+		{"example.com:8443", "example.com", "443", false},
+		{"10.0.0.0/8", "10.1.2.3", "80", true},
+		{"10.0.0.0/8", "11.1.2.3", "80", false},
+		{"10.0.0.0/8", "example.com", "80", false},
+		{"127.0.0.1", "127.0.0.1", "80", true},
+		{"127.0.0.1", "127.0.0.2", "80", false},
+		{"[::1]:8080", "::1", "8080", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			fmt.Sprintf("%s %s:%s", tt.pattern, tt.host, tt.port), func(t *testing.T) {
+				assert.Equal(t, matchProxyPattern(tt.pattern, tt.host, tt.port), tt.matches)
+			},
+		)
+	}
+}
+
+func TestProxyFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpsProxy string
+		noProxy    string
+		rules      []string
+		url        string
+		expected   string
+	}{
+		{name: "default proxy", url: "http://example.com", expected: "http://proxy:3128"},
+		{name: "default proxy for https", url: "https://example.com", expected: "http://proxy:3128"},
+		{name: "https proxy", httpsProxy: "https-proxy:3129", url: "https://example.com", expected: "http://https-proxy:3129"},
+		{name: "https proxy ignored for http", httpsProxy: "https-proxy:3129", url: "http://example.com", expected: "http://proxy:3128"},
+		{name: "no proxy", noProxy: "internal.local,10.0.0.0/8", url: "https://git.internal.local", expected: ""},
+		{name: "no proxy by address", noProxy: "internal.local,10.0.0.0/8", url: "http://10.1.2.3:8080", expected: ""},
+		{name: "no proxy with default port", noProxy: "example.com:443", url: "https://example.com", expected: ""},
+		{name: "rule to another proxy", rules: []string{"github.com=other:8080"}, url: "https://api.github.com", expected: "http://other:8080"},
+		{name: "direct rule", rules: []string{"github.com=direct"}, url: "https://api.github.com", expected: ""},
+		{
+			name:     "rule before no proxy",
+			noProxy:  "github.com",
+			rules:    []string{"github.com=other:8080"},
+			url:      "https://github.com",
+			expected: "http://other:8080",
+		},
+		{name: "first rule wins", rules: []string{"*.github.com=direct", "github.com=other:8080"}, url: "https://api.github.com", expected: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				router := useProxySettings(t, "proxy:3128", tt.httpsProxy, tt.noProxy, tt.rules...)
+				u, err := url.Parse(tt.url)
+				assert.NilError(t, err)
+
+				proxy := router.proxyFor(u)
+				if tt.expected == "" {
+					assert.Assert(t, proxy == nil, "%s should be reached directly, not through %s", tt.url, proxy)
+				} else {
+					assert.Assert(t, proxy != nil, "%s should go through %s", tt.url, tt.expected)
+					assert.Equal(t, proxy.String(), tt.expected)
+				}
+			},
+		)
+	}
+}
+
+func TestNewProxyRouterWithInvalidRule(t *testing.T) {
+	for _, rule := range []string{"github.com", "=direct", "github.com=://"} {
+		useSettings(t, map[string]interface{}{commonParams.ProxyRulesKey: []string{rule}})
+		_, err := newProxyRouter()
+		assert.ErrorContains(t, err, "Invalid proxy", rule)
+	}
+}
+
+func TestUsesDefaultProxy(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxy      string
+		httpsProxy string
+		noProxy    string
+		rules      []string
+		address    string
+		expected   bool
+	}{
+		{name: "default proxy", proxy: "proxy:3128", address: "example.com:443", expected: true},
+		{name: "without proxy", address: "example.com:443", expected: false},
+		{name: "no proxy", proxy: "proxy:3128", noProxy: "example.com", address: "example.com:443", expected: false},
+		{name: "direct rule", proxy: "proxy:3128", rules: []string{"example.com=direct"}, address: "example.com:443", expected: false},
+		{name: "rule to another proxy", proxy: "proxy:3128", rules: []string{"example.com=other:8080"}, address: "example.com:443", expected: false},
+		{name: "rule to the default proxy", proxy: "proxy:3128", rules: []string{"example.com=proxy:3128"}, address: "example.com:443", expected: true},
+		{name: "dialing the https proxy", proxy: "proxy:3128", httpsProxy: "https-proxy:3129", address: "https-proxy:3129", expected: false},
+		{name: "dialing a rule proxy", proxy: "proxy:3128", rules: []string{"github.com=other:8080"}, address: "other:8080", expected: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				router := useProxySettings(t, tt.proxy, tt.httpsProxy, tt.noProxy, tt.rules...)
+				assert.Equal(t, router.usesDefaultProxy(tt.address), tt.expected)
+			},
+		)
+	}
+}
+
+func TestTunnelProxyClientOnlyTunnelsThroughTheDefaultProxy(t *testing.T) {
+	target := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, "direct")
+			},
+		),
+	)
+	defer target.Close()
+	ruleProxy := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, "rule proxy")
+			},
+		),
+	)
+	defer ruleProxy.Close()
+	ruleProxyURL, _ := url.Parse(ruleProxy.URL)
+	router := useProxySettings(
+		t, "authenticating-proxy:3128", "", "127.0.0.1", "rule-routed.example.com="+ruleProxyURL.Host,
+	)
+	var tunneled []string
+	tunnel := func(ctx context.Context, network, address string) (net.Conn, error) {
+		tunneled = append(tunneled, address)
+		return nil, errors.New("tunneled")
+	}
+	client := tunnelProxyClient(5, router, nil, newProxyDialer(), tunnel)
+
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "direct host", url: target.URL, expected: "direct"},
+		{name: "rule-routed host", url: "http://rule-routed.example.com/", expected: "rule proxy"},
+	}
+	for _, tt := range tests {
+		resp, err := client.Get(tt.url)
+		assert.NilError(t, err, tt.name)
+		body := make([]byte, len(tt.expected))
+		_, _ = resp.Body.Read(body)
+		_ = resp.Body.Close()
+		assert.Equal(t, string(body), tt.expected, tt.name)
+	}
+	assert.Equal(t, len(tunneled), 0, "The authenticating dialer should not be used for %v", tunneled)
+
+	_, err := client.Get("http://example.com/")
+	assert.ErrorContains(t, err, "tunneled")
+	assert.DeepEqual(t, tunneled, []string{"example.com:80"})
+}
+
+func TestResolveProxy(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpsProxy string
+		proxyType  string
+		rules      []string
+		url        string
+		expected   string
+	}{
+		{name: "default proxy", url: "https://example.com", expected: "proxy:3128 basic"},
+		{name: "authenticating default proxy", proxyType: "ntlm", url: "https://example.com", expected: "proxy:3128 ntlm"},
+		{name: "https proxy", httpsProxy: "https-proxy:3129", proxyType: "negotiate", url: "https://example.com", expected: "https-proxy:3129 basic"},
+		{name: "rule to another proxy", proxyType: "ntlm", rules: []string{"example.com=other:8080"}, url: "https://example.com", expected: "other:8080 basic"},
+		{name: "rule to the default proxy", proxyType: "ntlm", rules: []string{"example.com=proxy:3128"}, url: "https://example.com", expected: "proxy:3128 ntlm"},
+		{name: "direct rule", rules: []string{"example.com=direct"}, url: "https://example.com", expected: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				useProxySettings(t, "proxy:3128", tt.httpsProxy, "", tt.rules...)
+				useSettings(t, map[string]interface{}{commonParams.ProxyTypeKey: tt.proxyType})
+
+				route, err := ResolveProxy(tt.url)
+				assert.NilError(t, err)
+				if tt.expected == "" {
+					assert.Assert(t, route == nil, "%s should be reached directly", tt.url)
+				} else {
+					assert.Assert(t, route != nil, "%s should go through %s", tt.url, tt.expected)
+					assert.Equal(t, route.URL.Host+" "+route.Type, tt.expected)
+				}
+			},
+		)
+	}
+}
+
+func TestConnectThroughProxy(t *testing.T) {
+	var tunnels []string
+	proxy := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodConnect {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				user, password, ok := parseProxyBasicAuth(r.Header.Get("Proxy-Authorization"))
+				if !ok || user != "user" || password != "pa55word" {
+					w.WriteHeader(http.StatusProxyAuthRequired)
+					return
+				}
+				tunnels = append(tunnels, r.Host)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					return
+				}
+				_, _ = fmt.Fprint(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+				_ = conn.Close()
+			},
+		),
+	)
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+	diagnostics := NewDiagnosticsHTTPWrapper()
+
+	useProxySettings(t, "http://user:pa55word@"+proxyURL.Host, "", "direct.example.com")
+	useSettings(t, map[string]interface{}{commonParams.ProxyTypeKey: ""})
+	assert.NilError(t, diagnostics.ConnectThroughProxy("https://example.com"))
+	assert.NilError(t, diagnostics.ConnectThroughProxy("http://example.com:8080/api"))
+	assert.DeepEqual(t, tunnels, []string{"example.com:443", "example.com:8080"})
+
+	err := diagnostics.ConnectThroughProxy("https://direct.example.com")
+	assert.ErrorContains(t, err, "is not reached through a proxy")
+
+	useProxySettings(t, "http://user:wrong@"+proxyURL.Host, "", "")
+	err = diagnostics.ConnectThroughProxy("https://example.com")
+	assert.ErrorContains(t, err, "407")
+}
+
+func parseProxyBasicAuth(header string) (user, password string, ok bool) {
+	r := &http.Request{Header: http.Header{"Authorization": []string{header}}}
+	return r.BasicAuth()
+}
@@ -45,6 +45,8 @@ func (r *ResultsHTTPWrapper) GetAllResultsByScanID(params map[string]string) (
 	}()
 
 	decoder := json.NewDecoder(resp.Body)
+	// keeps the query ids exact, they do not fit in a float64
+	decoder.UseNumber()
 
 	switch resp.StatusCode {
 	case http.StatusBadRequest, http.StatusInternalServerError:
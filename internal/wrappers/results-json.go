@@ -1,5 +1,9 @@
 package wrappers
 
+import (
+	"time"
+)
+
 type ScanResultsCollection struct {
 	Results    []*ScanResult `json:"results"`
 	TotalCount uint          `json:"totalCount"`
@@ -24,6 +28,29 @@ type ScanResult struct {
 	ScanResultData       ScanResultData       `json:"data,omitempty"`
 	Comments             ResultComments       `json:"comments,omitempty"`
 	VulnerabilityDetails VulnerabilityDetails `json:"vulnerabilityDetails,omitempty"`
+	TriageHistory        []ResultTriage       `json:"triageHistory,omitempty"`
+	Guidance             *ResultGuidance      `json:"guidance,omitempty"`
+	BestFixLocation      *ScanResultNode      `json:"bestFixLocation,omitempty"`
+}
+
+// ResultGuidance explains the query of a SAST result and how to fix it
+type ResultGuidance struct {
+	Risk                   string         `json:"risk,omitempty"`
+	Cause                  string         `json:"cause,omitempty"`
+	GeneralRecommendations string         `json:"generalRecommendations,omitempty"`
+	Samples                []SampleObject `json:"samples,omitempty"`
+	CodeBashingURL         string         `json:"codeBashingUrl,omitempty"`
+}
+
+// ResultTriage is a change to the state, severity or comment of a result, oldest first
+type ResultTriage struct {
+	State            string    `json:"state"`
+	PreviousState    string    `json:"previousState,omitempty"`
+	Severity         string    `json:"severity"`
+	PreviousSeverity string    `json:"previousSeverity,omitempty"`
+	Comment          string    `json:"comment,omitempty"`
+	CreatedBy        string    `json:"createdBy"`
+	CreatedAt        time.Time `json:"createdAt"`
 }
 
 type ResultComments struct {
@@ -51,19 +78,30 @@ type VulnerabilityCVSS struct {
 }
 
 type ScanResultNode struct {
-	ID          string `json:"id,omitempty"`
-	Line        uint   `json:"line,omitempty"`
-	Name        string `json:"name,omitempty"`
-	Column      uint   `json:"column"`
-	Length      uint   `json:"length,omitempty"`
-	Method      string `json:"method,omitempty"`
-	NodeID      int    `json:"nodeID,omitempty"`
-	DomType     string `json:"domType,omitempty"`
-	FileName    string `json:"fileName,omitempty"`
-	FullName    string `json:"fullName,omitempty"`
-	TypeName    string `json:"typeName,omitempty"`
-	MethodLine  uint   `json:"methodLine,omitempty"`
-	Definitions string `json:"definitions,omitempty"`
+	ID          string       `json:"id,omitempty"`
+	Line        uint         `json:"line,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Column      uint         `json:"column"`
+	Length      uint         `json:"length,omitempty"`
+	Method      string       `json:"method,omitempty"`
+	NodeID      int          `json:"nodeID,omitempty"`
+	DomType     string       `json:"domType,omitempty"`
+	FileName    string       `json:"fileName,omitempty"`
+	FullName    string       `json:"fullName,omitempty"`
+	TypeName    string       `json:"typeName,omitempty"`
+	MethodLine  uint         `json:"methodLine,omitempty"`
+	Definitions string       `json:"definitions,omitempty"`
+	Snippet     *CodeSnippet `json:"snippet,omitempty"`
+}
+
+// CodeSnippet is the code around a result line, read from the local sources. Changed tells that the line no longer
+// matches the scanned code.
+type CodeSnippet struct {
+	FileName  string   `json:"fileName"`
+	Line      uint     `json:"line"`
+	StartLine uint     `json:"startLine"`
+	Lines     []string `json:"lines"`
+	Changed   bool     `json:"changed,omitempty"`
 }
 
 type ScanResultPackageData struct {
@@ -86,10 +124,11 @@ type ScanResultData struct {
 	ScaPackageCollection *ScaPackageCollection    `json:"scaPackageData,omitempty"`
 	RecommendedVersion   interface{}              `json:"recommendedVersion,omitempty"`
 	// Added to support kics results
-	Line          uint   `json:"line,omitempty"`
-	Platform      string `json:"platform,omitempty"`
-	IssueType     string `json:"issueType,omitempty"`
-	ExpectedValue string `json:"expectedValue,omitempty"`
-	Value         string `json:"value,omitempty"`
-	Filename      string `json:"filename,omitempty"`
+	Line          uint         `json:"line,omitempty"`
+	Platform      string       `json:"platform,omitempty"`
+	IssueType     string       `json:"issueType,omitempty"`
+	ExpectedValue string       `json:"expectedValue,omitempty"`
+	Value         string       `json:"value,omitempty"`
+	Filename      string       `json:"filename,omitempty"`
+	Snippet       *CodeSnippet `json:"snippet,omitempty"`
 }
@@ -1,26 +1,73 @@
 package wrappers
 
 type ResultSummary struct {
-	TotalIssues     int
-	HighIssues      int
-	MediumIssues    int
-	LowIssues       int
-	SastIssues      int
-	KicsIssues      int
-	ScaIssues       int
-	RiskStyle       string
-	RiskMsg         string
-	Status          string
-	ScanID          string
-	ScanDate        string
-	ScanTime        string
-	CreatedAt       string
-	ProjectID       string
-	BaseURI         string
-	Tags            map[string]string
-	ProjectName     string
-	BranchName      string
-	ScanInfoMessage string
+	TotalIssues      int
+	HighIssues       int
+	MediumIssues     int
+	LowIssues        int
+	SastIssues       int
+	KicsIssues       int
+	ScaIssues        int
+	RiskStyle        string
+	RiskMsg          string
+	Status           string
+	ScanID           string
+	ScanDate         string
+	ScanTime         string
+	CreatedAt        string
+	ProjectID        string
+	BaseURI          string
+	Tags             map[string]string
+	ProjectName      string
+	BranchName       string
+	ScanInfoMessage  string
+	TriagedResults   []*TriagedResultSummary   `json:",omitempty"`
+	Guidance         []*GuidanceSummary        `json:",omitempty"`
+	BestFixLocations []*BestFixLocationSummary `json:",omitempty"`
+	Snippets         []*SnippetSummary         `json:",omitempty"`
+}
+
+// TriagedResultSummary describes the latest triage of a result for the summary reports
+type TriagedResultSummary struct {
+	Type      string
+	Name      string
+	Severity  string
+	State     string
+	Comment   string
+	CreatedBy string
+	CreatedAt string
+	Changes   int
+}
+
+// GuidanceSummary describes how to fix the results of a SAST query for the summary reports
+type GuidanceSummary struct {
+	QueryName              string
+	Language               string
+	Results                int
+	Risk                   string
+	GeneralRecommendations string
+	CodeBashingURL         string
+}
+
+// BestFixLocationSummary is a code location where a single fix clears several SAST results
+type BestFixLocationSummary struct {
+	FileName string
+	Line     uint
+	Column   uint
+	Name     string
+	Queries  []string
+	Results  int
+}
+
+// SnippetSummary is the code at the main location of a result for the summary reports
+type SnippetSummary struct {
+	Type     string
+	Severity string
+	Name     string
+	FileName string
+	Line     uint
+	Code     string
+	Changed  bool
 }
 
 const summaryTemplateHeader = `{{define "SummaryTemplate"}}
@@ -453,6 +500,113 @@ const asyncSummaryTemplate = `<div class="cx-info">
             </div>
         </div>`
 
+// The summary is rendered with text/template, so the triage values written by users are escaped explicitly
+const triagedResultsSummary = `{{if .TriagedResults}}
+        <div class="cx-info" style="display: block;">
+            <div class="total">Triage History</div>
+            <table style="width: 100%; text-align: left; border-collapse: collapse;">
+                <tr>
+                    <th>Type</th>
+                    <th>Vulnerability</th>
+                    <th>Severity</th>
+                    <th>State</th>
+                    <th>Changes</th>
+                    <th>Last change by</th>
+                    <th>Last change at</th>
+                    <th>Comment</th>
+                </tr>
+                {{range .TriagedResults}}
+                <tr>
+                    <td>{{html .Type}}</td>
+                    <td>{{html .Name}}</td>
+                    <td>{{html .Severity}}</td>
+                    <td>{{html .State}}</td>
+                    <td>{{.Changes}}</td>
+                    <td>{{html .CreatedBy}}</td>
+                    <td>{{html .CreatedAt}}</td>
+                    <td>{{html .Comment}}</td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+        {{end}}`
+
+const guidanceSummary = `{{if .Guidance}}
+        <div class="cx-info" style="display: block;">
+            <div class="total">Remediation Guidance</div>
+            <table style="width: 100%; text-align: left; border-collapse: collapse;">
+                <tr>
+                    <th>Vulnerability</th>
+                    <th>Language</th>
+                    <th>Results</th>
+                    <th>Risk</th>
+                    <th>Recommendations</th>
+                    <th>Lesson</th>
+                </tr>
+                {{range .Guidance}}
+                <tr>
+                    <td>{{html .QueryName}}</td>
+                    <td>{{html .Language}}</td>
+                    <td>{{.Results}}</td>
+                    <td>{{html .Risk}}</td>
+                    <td>{{html .GeneralRecommendations}}</td>
+                    <td>{{if .CodeBashingURL}}<a href="{{html .CodeBashingURL}}" target="_blank">CodeBashing</a>{{end}}</td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+        {{end}}`
+
+const bestFixLocationsSummary = `{{if .BestFixLocations}}
+        <div class="cx-info" style="display: block;">
+            <div class="total">Best Fix Locations</div>
+            <table style="width: 100%; text-align: left; border-collapse: collapse;">
+                <tr>
+                    <th>File</th>
+                    <th>Line</th>
+                    <th>Column</th>
+                    <th>Element</th>
+                    <th>Vulnerabilities</th>
+                    <th>Results</th>
+                </tr>
+                {{range .BestFixLocations}}
+                <tr>
+                    <td>{{html .FileName}}</td>
+                    <td>{{.Line}}</td>
+                    <td>{{.Column}}</td>
+                    <td>{{html .Name}}</td>
+                    <td>{{range $i, $query := .Queries}}{{if $i}}, {{end}}{{html $query}}{{end}}</td>
+                    <td>{{.Results}}</td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+        {{end}}`
+
+const snippetsSummary = `{{if .Snippets}}
+        <div class="cx-info" style="display: block;">
+            <div class="total">Code Snippets</div>
+            <table style="width: 100%; text-align: left; border-collapse: collapse;">
+                <tr>
+                    <th>Type</th>
+                    <th>Vulnerability</th>
+                    <th>Severity</th>
+                    <th>Location</th>
+                    <th>Code</th>
+                </tr>
+                {{range .Snippets}}
+                <tr>
+                    <td>{{html .Type}}</td>
+                    <td>{{html .Name}}</td>
+                    <td>{{html .Severity}}</td>
+                    <td>{{html .FileName}}:{{.Line}}{{if .Changed}} (changed since the scan){{end}}</td>
+                    <td><pre>{{html .Code}}</pre></td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+        {{end}}`
+
 const summaryTemplateFooter = `</div>
 </body>
 {{end}}
@@ -462,6 +616,10 @@ func SummaryTemplate(isScanPending bool) string {
 	result := summaryTemplateHeader
 	if !isScanPending {
 		result += nonAsyncSummary
+		result += triagedResultsSummary
+		result += guidanceSummary
+		result += bestFixLocationsSummary
+		result += snippetsSummary
 	} else {
 		result += asyncSummaryTemplate
 	}
@@ -1,6 +1,27 @@
 package wrappers
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 type ResultsWrapper interface {
 	GetAllResultsByScanID(params map[string]string) (*ScanResultsCollection, *WebError, error)
 	GetAllResultsPackageByScanID(params map[string]string) (*[]ScaPackageCollection, *WebError, error)
 }
+
+// FormatQueryID returns the query id of a result as the API sends it. The SAST ids do not fit in a float64, so the
+// results are decoded as json.Number; a float64 is still printed without an exponent.
+func FormatQueryID(queryID interface{}) string {
+	switch id := queryID.(type) {
+	case nil:
+		return ""
+	case json.Number:
+		return id.String()
+	case float64:
+		return strconv.FormatFloat(id, 'f', -1, 64)
+	default:
+		return fmt.Sprint(id)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build !integration
+
+package wrappers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestFormatQueryID(t *testing.T) {
+	payload := `{"queryId": 5157925289005576664}`
+	var numberData ScanResultData
+	decoder := json.NewDecoder(strings.NewReader(payload))
+	decoder.UseNumber()
+	assert.NilError(t, decoder.Decode(&numberData))
+	var floatData ScanResultData
+	assert.NilError(t, json.Unmarshal([]byte(payload), &floatData))
+
+	tests := []struct {
+		name     string
+		queryID  interface{}
+		expected string
+	}{
+		{"decoded as json.Number", numberData.QueryID, "5157925289005576664"},
+		// without UseNumber the id loses its last digits, but is never printed as 5.157925289005576e+18
+		{"decoded as float64", floatData.QueryID, "5157925289005576000"},
+		{"small float64", float64(12), "12"},
+		{"int", 7, "7"},
+		{"string", "abc", "abc"},
+		{"missing", nil, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(
+			tt.name, func(t *testing.T) {
+				assert.Equal(t, FormatQueryID(tt.queryID), tt.expected)
+			},
+		)
+	}
+}
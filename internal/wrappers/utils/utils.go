@@ -0,0 +1,13 @@
+package utils
+
+import "strings"
+
+// ContainsFold reports whether value is one of values, ignoring the case
+func ContainsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
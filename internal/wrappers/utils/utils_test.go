@@ -0,0 +1,18 @@
+//go:build !integration
+
+package utils
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestContainsFold(t *testing.T) {
+	values := []string{"sast", "KICS"}
+	assert.Assert(t, ContainsFold(values, "sast"))
+	assert.Assert(t, ContainsFold(values, "SAST"))
+	assert.Assert(t, ContainsFold(values, "kics"))
+	assert.Assert(t, !ContainsFold(values, "sca"))
+	assert.Assert(t, !ContainsFold(nil, "sast"))
+}
@@ -21,7 +21,7 @@ func TestGetLearnMoreInformationFailureMissingQueryId(t *testing.T) {
 	err, _ := executeCommand(
 		t, "utils", "learn-more",
 		flag(params.FormatFlag), "json")
-	assertError(t, err, "required flag(s) \"query-id\" not set")
+	assertError(t, err, "Please provide --query-id or --scan-id")
 }
 
 func TestGetLearnMoreInformationSuccessCaseJson(t *testing.T) {
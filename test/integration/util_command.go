@@ -72,6 +72,13 @@ func createASTIntegrationTestCommand(t *testing.T) *cobra.Command {
 	bitBucketWrapper := wrappers.NewBitbucketWrapper()
 	bflWrapper := wrappers.NewBflHTTPWrapper(bfl)
 	learnMoreWrapper := wrappers.NewHTTPLearnMoreWrapper(learnMore)
+	hooksWrapper := wrappers.NewHTTPHooksWrapper()
+	healthCheckWrapper := wrappers.NewHTTPHealthCheckWrapper(
+		viper.GetString(params.HealthcheckPathKey),
+		viper.GetString(params.AstWebAppHealthCheckPathKey),
+		viper.GetString(params.AstKeycloakWebAppHealthCheckPathKey),
+	)
+	diagnosticsWrapper := wrappers.NewDiagnosticsHTTPWrapper()
 
 	astCli := commands.NewAstCLI(
 		scansWrapper,
@@ -89,6 +96,9 @@ func createASTIntegrationTestCommand(t *testing.T) *cobra.Command {
 		gitLabWrapper,
 		bflWrapper,
 		learnMoreWrapper,
+		hooksWrapper,
+		healthCheckWrapper,
+		diagnosticsWrapper,
 	)
 	return astCli
 }